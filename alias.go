@@ -18,7 +18,11 @@ func (tree *figTree) resolveName(name string) string {
 func (tree *figTree) Problems() []error {
 	tree.mu.RLock()
 	defer tree.mu.RUnlock()
-	return append([]error(nil), tree.problems...)
+	problems := make([]error, 0, len(tree.problems))
+	for _, problem := range tree.problems {
+		problems = append(problems, tree.redact(problem))
+	}
+	return problems
 }
 
 func (tree *figTree) WithAlias(name, alias string) Plant {
@@ -0,0 +1,193 @@
+package figtree
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// figArg is a positional argument registered by NewArg or NewArgs
+type figArg struct {
+	name        string
+	usage       string
+	mutagenesis Mutagenesis
+	variadic    bool
+}
+
+// argMutagenesis lists the types a positional argument can be parsed into
+var argMutagenesis = []Mutagenesis{tString, tBool, tInt, tInt64, tFloat64, tDuration, tFile, tDirectory}
+
+// NewArg registers the required positional argument name whose value is parsed into mut
+//
+// Example:
+//
+//	figs := figtree.Grow()
+//	figs.NewBool("force", false, "overwrite the destination")
+//	figs.NewArg("source", figtree.MutagenesisOf(""), "input file")
+//	figs.NewArg("retries", figtree.MutagenesisOf(0), "number of retries")
+//	figs.WithValidator("source", figtree.AssureStringHasSuffix(".csv"))
+//	err := figs.Parse() // app -force data.csv 3
+//	source := *figs.String("source")
+//
+// Positional arguments are read in the order they are registered from the arguments left after the flags and are
+// checked by validateAll like any other fig. Once an argument is registered, a missing or an unexpected argument
+// makes Parse and Load fail. Environment variables and config files never set an argument and SaveTo leaves it out.
+func (tree *figTree) NewArg(name string, mut Mutagenesis, usage string) Plant {
+	return tree.newArg(name, mut, usage, false)
+}
+
+// NewArgs registers name as a List that collects every remaining positional argument after those of NewArg
+//
+// Each argument must parse into mut. Validators of name receive the whole List.
+func (tree *figTree) NewArgs(name string, mut Mutagenesis, usage string) Plant {
+	return tree.newArg(name, mut, usage, true)
+}
+
+// Args returns the positional arguments that followed the flags of the last Parse or Load
+func (tree *figTree) Args() []string {
+	tree.mu.RLock()
+	defer tree.mu.RUnlock()
+	return append([]string{}, tree.positional...)
+}
+
+func (tree *figTree) newArg(name string, mut Mutagenesis, usage string, variadic bool) Plant {
+	tree.mu.Lock()
+	defer tree.mu.Unlock()
+	name = strings.ToLower(name)
+	way := "NewArg"
+	if variadic {
+		way = "NewArgs"
+	}
+	if _, exists := tree.figs[name]; exists {
+		tree.problems = append(tree.problems, fmt.Errorf("%s: name '%s' already exists", way, name))
+		return tree
+	}
+	known := false
+	for _, m := range argMutagenesis {
+		known = known || m == mut
+	}
+	if !known {
+		tree.problems = append(tree.problems, fmt.Errorf("%s: -%s cannot be a %s", way, name, mut))
+		return tree
+	}
+	if n := len(tree.argDefs); n > 0 && tree.argDefs[n-1].variadic {
+		tree.problems = append(tree.problems, fmt.Errorf("%s: -%s follows the variadic argument %s", way, name, tree.argDefs[n-1].name))
+		return tree
+	}
+	v := &Value{Value: zeroOf(mut), Mutagensis: mut}
+	fruitMut := mut
+	if variadic {
+		v = &Value{Value: ListFlag{values: []string{}}, Mutagensis: tList}
+		fruitMut = tList
+	}
+	tree.values.Store(name, v)
+	tree.figs[name] = &figFruit{
+		name:        name,
+		usage:       usage,
+		Mutagenesis: fruitMut,
+		Mutations:   make([]Mutation, 0),
+		Validators:  make([]FigValidatorFunc, 0),
+		Callbacks:   make([]Callback, 0),
+		Rules:       tree.branchRules(name),
+	}
+	if _, exists := tree.withered[name]; !exists {
+		tree.withered[name] = witheredFig{
+			name:        name,
+			Value:       *v,
+			Mutagenesis: fruitMut,
+		}
+	}
+	tree.argDefs = append(tree.argDefs, &figArg{name: name, usage: usage, mutagenesis: mut, variadic: variadic})
+	return tree
+}
+
+// bindArgs remembers the positional arguments left by the flagSet and assigns them to the figs of NewArg and NewArgs
+func (tree *figTree) bindArgs() error {
+	tree.mu.Lock()
+	defer tree.mu.Unlock()
+	tree.positional = append([]string{}, tree.flagSet.Args()...)
+	if len(tree.commands) > 0 {
+		tree.positional = tree.positional[:0] // the arguments belong to the selected command
+	}
+	if len(tree.argDefs) == 0 {
+		return nil
+	}
+	rest := tree.positional
+	for _, def := range tree.argDefs {
+		value, err := tree.from(def.name)
+		if err != nil {
+			return err
+		}
+		if def.variadic {
+			for _, arg := range rest {
+				check := &Value{Mutagensis: def.mutagenesis}
+				if err := check.Set(arg); err != nil {
+					return fmt.Errorf("argument %s: %w", def.name, err)
+				}
+			}
+			if err := value.Assign(append([]string{}, rest...)); err != nil {
+				return fmt.Errorf("argument %s: %w", def.name, err)
+			}
+			tree.trace(def.name, Provenance{Source: SourceArg, Origin: argForm(def)})
+			rest = nil
+			break
+		}
+		if len(rest) == 0 {
+			return fmt.Errorf("missing argument <%s>", def.name)
+		}
+		if err := value.Set(rest[0]); err != nil {
+			return fmt.Errorf("argument %s: %w", def.name, err)
+		}
+		tree.trace(def.name, Provenance{Source: SourceArg, Origin: argForm(def)})
+		rest = rest[1:]
+	}
+	if len(rest) > 0 {
+		return fmt.Errorf("unexpected argument '%s'", rest[0])
+	}
+	return nil
+}
+
+// isArg requires the figTree.mu to be locked and reports whether name was registered by NewArg or NewArgs
+func (tree *figTree) isArg(name string) bool {
+	for _, def := range tree.argDefs {
+		if def.name == name {
+			return true
+		}
+	}
+	return false
+}
+
+// argsUsage requires the figTree.mu to be locked and renders the positional arguments like <source> [files...]
+func (tree *figTree) argsUsage() string {
+	forms := make([]string, 0, len(tree.argDefs))
+	for _, def := range tree.argDefs {
+		forms = append(forms, argForm(def))
+	}
+	return strings.Join(forms, " ")
+}
+
+// zeroOf returns the zero value a positional argument of mut holds until it is parsed
+func zeroOf(mut Mutagenesis) interface{} {
+	switch mut {
+	case tBool:
+		return false
+	case tInt:
+		return 0
+	case tInt64:
+		return int64(0)
+	case tFloat64:
+		return float64(0)
+	case tDuration:
+		return time.Duration(0)
+	default:
+		return ""
+	}
+}
+
+// argForm renders a positional argument as <name> or [name...] when it is variadic
+func argForm(def *figArg) string {
+	if def.variadic {
+		return "[" + def.name + "...]"
+	}
+	return "<" + def.name + ">"
+}
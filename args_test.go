@@ -0,0 +1,108 @@
+package figtree
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTree_NewArg(t *testing.T) {
+	os.Args = []string{os.Args[0]}
+	newFigs := func() Plant {
+		figs := With(Options{Germinate: true, IgnoreEnvironment: true})
+		figs.NewBool("force", false, "overwrite the destination")
+		figs.NewArg("source", tString, "input file")
+		figs.NewArg("retries", tInt, "number of retries")
+		figs.NewArgs("files", tString, "extra files")
+		figs.WithValidator("source", AssureStringHasSuffix(".csv"))
+		figs.WithValidator("retries", AssureIntInRange(0, 5))
+		return figs
+	}
+
+	figs := newFigs()
+	assert.NoError(t, figs.ParseArgs([]string{"-force", "data.csv", "3", "a.txt", "b.txt"}))
+	assert.True(t, *figs.Bool("force"))
+	assert.Equal(t, "data.csv", *figs.String("source"))
+	assert.Equal(t, 3, *figs.Int("retries"))
+	assert.Equal(t, []string{"a.txt", "b.txt"}, *figs.List("files"))
+	assert.Equal(t, []string{"data.csv", "3", "a.txt", "b.txt"}, figs.Args())
+
+	figs = newFigs()
+	assert.NoError(t, figs.ParseArgs([]string{"data.csv", "0"}))
+	assert.Empty(t, *figs.List("files"))
+
+	assert.ErrorContains(t, newFigs().ParseArgs([]string{"data.csv"}), "missing argument <retries>")
+	assert.ErrorContains(t, newFigs().ParseArgs([]string{"data.csv", "three"}), "argument retries")
+	assert.Error(t, newFigs().ParseArgs([]string{"data.txt", "1"}))
+	assert.Error(t, newFigs().ParseArgs([]string{"data.csv", "9"}))
+	assert.Error(t, newFigs().LoadWithArgs([]string{}))
+
+	figs = With(Options{Germinate: true, IgnoreEnvironment: true})
+	figs.NewArg("wait", tDuration, "time to wait")
+	assert.ErrorContains(t, figs.ParseArgs([]string{"1s", "extra"}), "unexpected argument 'extra'")
+	assert.NoError(t, figs.ParseArgs([]string{"1s"}))
+	assert.Equal(t, time.Second, *figs.Duration("wait"))
+	assert.Error(t, figs.ParseArgs([]string{"-wait", "2s"}))
+}
+
+func TestTree_NewArg_Sources(t *testing.T) {
+	os.Args = []string{os.Args[0]}
+	dir := t.TempDir()
+	config := filepath.Join(dir, "config.yaml")
+	assert.NoError(t, os.WriteFile(config, []byte("source: /from/file\npath: /from/file\n"), 0644))
+	env := MapEnv{"SOURCE": "/from/env", "PATH": "/usr/bin:/bin"}
+	grow := func() Plant {
+		figs := With(Options{Germinate: true, Pollinate: true, EnvSource: env, ConfigFile: config})
+		figs.NewArg("source", tString, "input file")
+		figs.NewArg("path", tString, "output path")
+		return figs
+	}
+
+	figs := grow()
+	assert.NoError(t, figs.ParseArgs([]string{"data.csv", "out"}))
+	assert.Equal(t, "data.csv", *figs.String("source"))
+	assert.Equal(t, "out", *figs.String("path"))
+
+	figs = grow()
+	assert.NoError(t, figs.LoadWithArgs([]string{"data.csv", "out"}))
+	assert.Equal(t, "data.csv", *figs.String("source"))
+	assert.Equal(t, "out", *figs.String("path"))
+	assert.Equal(t, "argument <source>", figs.SourceOf("source").String())
+
+	saved := filepath.Join(dir, "saved.yaml")
+	assert.NoError(t, figs.SaveTo(saved))
+	data, err := os.ReadFile(saved)
+	assert.NoError(t, err)
+	assert.NotContains(t, string(data), "data.csv")
+}
+
+func TestTree_NewArg_Problems(t *testing.T) {
+	os.Args = []string{os.Args[0]}
+	figs := With(Options{Germinate: true, IgnoreEnvironment: true})
+	figs.NewString("name", "", "name")
+	figs.NewArg("name", tString, "duplicate")
+	figs.NewArg("pairs", tMap, "maps are not positional")
+	figs.NewArgs("rest", tString, "rest")
+	figs.NewArg("after", tString, "after the variadic argument")
+	assert.Len(t, figs.Problems(), 3)
+
+	figs = With(Options{Germinate: true, IgnoreEnvironment: true})
+	figs.NewString("name", "", "name")
+	assert.NoError(t, figs.ParseArgs([]string{"-name", "x", "ignored"}))
+	assert.Equal(t, []string{"ignored"}, figs.Args())
+}
+
+func TestTree_NewArg_Usage(t *testing.T) {
+	os.Args = []string{os.Args[0]}
+	figs := With(Options{Germinate: true, IgnoreEnvironment: true})
+	figs.NewBool("force", false, "overwrite the destination")
+	figs.NewArg("source", tString, "input file")
+	figs.NewArgs("files", tFile, "extra files")
+	usage := figs.UsageString()
+	assert.Contains(t, usage, " <source> [files...] (powered by")
+	assert.Contains(t, usage, "\nArguments:\n   <source>   [String]   input file\n   [files...]   [...File]   extra files\n")
+	assert.NotContains(t, usage, "-source")
+}
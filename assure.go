@@ -3,8 +3,12 @@ package figtree
 import (
 	"fmt"
 	"math"
+	"os"
 	"strings"
 	"time"
+
+	"github.com/andreimerlescu/checkfs/directory"
+	"github.com/andreimerlescu/checkfs/file"
 )
 
 // AssureStringHasSuffix ensures a string ends with the given suffix.
@@ -780,3 +784,190 @@ var AssureMapNotLength = func(length int) FigValidatorFunc {
 		return nil
 	}
 }
+
+// AssureFileExists ensures a file path points to an existing regular file.
+// Returns an error if the file is missing or the value is not a path.
+var AssureFileExists = makeFileValidator(file.Options{Exists: true})
+
+// AssureFileTouchIfNotExists creates an empty file at the path when it does not exist.
+// Returns an error if the file cannot be created or the value is not a path.
+var AssureFileTouchIfNotExists = func() FigValidatorFunc {
+	return makeFileValidator(file.Options{Create: file.Create{
+		Kind:     file.IfNotExists,
+		OpenFlag: os.O_CREATE | os.O_WRONLY,
+		FileMode: 0644,
+	}})
+}
+
+// AssureFileCanBeModified ensures a file exists and is writable by its owner.
+// Returns an error if the file is missing, read-only, or the value is not a path.
+var AssureFileCanBeModified = makeFileValidator(file.Options{Exists: true, RequireWrite: true})
+
+// AssureFileSizeGreaterThan ensures a file is larger than (but not including) size bytes.
+// Returns an error if the file is missing, too small, or the value is not a path.
+var AssureFileSizeGreaterThan = func(size int64) FigValidatorFunc {
+	return func(value interface{}) error {
+		v := figFlesh{value, nil}
+		if !v.IsString() {
+			return ErrInvalidType{tFile, value}
+		}
+		path := v.ToString()
+		info, err := os.Stat(path)
+		if err != nil {
+			return err
+		}
+		if info.Size() <= size {
+			return fmt.Errorf("file size %d must be greater than %d: %s", info.Size(), size, path)
+		}
+		return nil
+	}
+}
+
+// AssureFileSizeLessThan ensures a file is smaller than (but not including) size bytes.
+// Returns an error if the file is missing, too large, or the value is not a path.
+var AssureFileSizeLessThan = func(size int64) FigValidatorFunc {
+	return func(value interface{}) error {
+		v := figFlesh{value, nil}
+		if !v.IsString() {
+			return ErrInvalidType{tFile, value}
+		}
+		path := v.ToString()
+		info, err := os.Stat(path)
+		if err != nil {
+			return err
+		}
+		if info.Size() >= size {
+			return fmt.Errorf("file size %d must be less than %d: %s", info.Size(), size, path)
+		}
+		return nil
+	}
+}
+
+// AssureFileModeIs ensures a file has exactly the os.FileMode provided.
+// Returns an error if the file is missing, has another mode, or the value is not a path.
+var AssureFileModeIs = func(mode os.FileMode) FigValidatorFunc {
+	return makeFileValidator(file.Options{Exists: true, IsFileMode: mode})
+}
+
+// AssureFileOwnerIs ensures a file is owned by the user name or uid provided.
+// Returns an error if the file is missing, owned by someone else, or the value is not a path.
+var AssureFileOwnerIs = func(owner string) FigValidatorFunc {
+	return makeFileValidator(file.Options{Exists: true, RequireOwner: lookupOwner(owner)})
+}
+
+// AssureFileGroupIs ensures a file belongs to the group name or gid provided.
+// Returns an error if the file is missing, in another group, or the value is not a path.
+var AssureFileGroupIs = func(group string) FigValidatorFunc {
+	return makeFileValidator(file.Options{Exists: true, RequireGroup: lookupGroup(group)})
+}
+
+// AssureDirExists ensures a directory path points to an existing directory.
+// Returns an error if the directory is missing or the value is not a path.
+var AssureDirExists = makeDirectoryValidator(directory.Options{Exists: true})
+
+// AssureDirCreateIfNotExists creates the directory at the path when it does not exist.
+// Returns an error if the directory cannot be created or the value is not a path.
+var AssureDirCreateIfNotExists = makeDirectoryValidator(directory.Options{
+	Exists:     true,
+	WillCreate: true,
+	Create: directory.Create{
+		Kind:     directory.IfNotExists,
+		FileMode: 0755,
+	},
+})
+
+// AssureDirIsReadable ensures a directory exists and is readable by its owner.
+// Returns an error if the directory is missing, unreadable, or the value is not a path.
+var AssureDirIsReadable = makeDirectoryValidator(directory.Options{Exists: true, MorePermissiveThan: 0400})
+
+// AssureDirIsWritable ensures a directory exists and is writable by its owner.
+// Returns an error if the directory is missing, read-only, or the value is not a path.
+var AssureDirIsWritable = makeDirectoryValidator(directory.Options{Exists: true, RequireWrite: true})
+
+// AssureDirOwnerIs ensures a directory is owned by the user name or uid provided.
+// Returns an error if the directory is missing, owned by someone else, or the value is not a path.
+var AssureDirOwnerIs = func(owner string) FigValidatorFunc {
+	return makeDirectoryValidator(directory.Options{Exists: true, RequireOwner: lookupOwner(owner)})
+}
+
+// AssureDirGroupIs ensures a directory belongs to the group name or gid provided.
+// Returns an error if the directory is missing, in another group, or the value is not a path.
+var AssureDirGroupIs = func(group string) FigValidatorFunc {
+	return makeDirectoryValidator(directory.Options{Exists: true, RequireGroup: lookupGroup(group)})
+}
+
+// AssureDirChmod ensures a directory has exactly the permission bits provided.
+// Returns an error if the directory is missing, has other permissions, or the value is not a path.
+var AssureDirChmod = func(mode os.FileMode) FigValidatorFunc {
+	return func(value interface{}) error {
+		if err := AssureDirExists(value); err != nil {
+			return err
+		}
+		path := figFlesh{value, nil}
+		info, err := os.Stat(path.ToString())
+		if err != nil {
+			return err
+		}
+		if info.Mode().Perm() != mode.Perm() {
+			return fmt.Errorf("directory mode for %s must be %o, got %o", path.ToString(), mode.Perm(), info.Mode().Perm())
+		}
+		return nil
+	}
+}
+
+// AssureDirMorePermissiveThan ensures a directory grants at least the permission bits provided.
+// Returns an error if the directory is missing, less permissive, or the value is not a path.
+var AssureDirMorePermissiveThan = func(mode os.FileMode) FigValidatorFunc {
+	return makeDirectoryValidator(directory.Options{Exists: true, MorePermissiveThan: mode})
+}
+
+// AssureDirLessPermissiveThan ensures a directory grants no more than the permission bits provided.
+// Returns an error if the directory is missing, more permissive, or the value is not a path.
+var AssureDirLessPermissiveThan = func(mode os.FileMode) FigValidatorFunc {
+	return makeDirectoryValidator(directory.Options{Exists: true, LessPermissiveThan: mode})
+}
+
+// AssureSemaphoreLessThan ensures a Semaphore limit is less than a specified value (exclusive).
+// Returns an error if the limit is too high or the value is not a Semaphore limit.
+var AssureSemaphoreLessThan = func(below int) FigValidatorFunc {
+	return func(value interface{}) error {
+		limit, ok := semaphoreLimitOf(value)
+		if !ok {
+			return ErrInvalidType{tSemaphore, value}
+		}
+		if limit >= below {
+			return ErrValue{ErrWayBeBelow, limit, below}
+		}
+		return nil
+	}
+}
+
+// AssureSemaphoreGreaterThan ensures a Semaphore limit is greater than a specified value (exclusive).
+// Returns an error if the limit is too low or the value is not a Semaphore limit.
+var AssureSemaphoreGreaterThan = func(above int) FigValidatorFunc {
+	return func(value interface{}) error {
+		limit, ok := semaphoreLimitOf(value)
+		if !ok {
+			return ErrInvalidType{tSemaphore, value}
+		}
+		if limit <= above {
+			return ErrValue{ErrWayBeAbove, limit, above}
+		}
+		return nil
+	}
+}
+
+// AssureSemaphoreIs ensures a Semaphore limit is exactly the specified value.
+// Returns an error if the limit differs or the value is not a Semaphore limit.
+var AssureSemaphoreIs = func(is int) FigValidatorFunc {
+	return func(value interface{}) error {
+		limit, ok := semaphoreLimitOf(value)
+		if !ok {
+			return ErrInvalidType{tSemaphore, value}
+		}
+		if limit != is {
+			return ErrValue{fmt.Sprintf("be %d", is), limit, nil}
+		}
+		return nil
+	}
+}
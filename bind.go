@@ -0,0 +1,195 @@
+package figtree
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+)
+
+// TagDefault is the struct tag that holds the default value of a bound fig
+const TagDefault = "default"
+
+// TagUsage is the struct tag that holds the usage of a bound fig
+const TagUsage = "usage"
+
+// TagAlias is the struct tag that holds the comma separated aliases of a bound fig
+const TagAlias = "alias"
+
+// TagRules is the struct tag that holds the | separated rules of a bound fig like preventChange|noCallbacks
+const TagRules = "rules"
+
+// figBinding is a struct field that Bind keeps in sync with the value of a fig
+type figBinding struct {
+	field       reflect.Value
+	mutagenesis Mutagenesis
+}
+
+// Bind registers one fig per field of the struct that ptr points to and keeps the fields in sync
+//
+// Example:
+//
+//	type Config struct {
+//		Port    int           `fig:"port" default:"8080" usage:"listen port" alias:"p" assure:"inRange=1024,65535"`
+//		Secret  string        `fig:"secret" usage:"signing secret" rules:"preventChange" assure:"notEmpty"`
+//		Timeout time.Duration `fig:"timeout" default:"30s" usage:"request timeout"`
+//	}
+//	var cfg Config
+//	figs := figtree.Grow()
+//	if err := figs.Bind(&cfg); err != nil {
+//		log.Fatal(err)
+//	}
+//	err := figs.Load() // cfg.Port now holds -port, PORT or the port from the config file
+//
+// Fields use the same fig: naming as Unmarshal, including dotted names for nested structs. Without a
+// default: tag the current value of the field is used as the default. Bound fields are updated whenever
+// Store, Parse, ParseFile, Load, LoadFile, ReadFrom or Reload changes the value of their fig, so read the
+// struct from the goroutine that calls those methods or copy it from a Mutations() consumer. Watch and
+// Options.Watch refuse a figTree with bound structs since the watcher goroutine would write into them while
+// the application reads them, and Bind refuses a figTree that is already watching.
+func (tree *figTree) Bind(ptr interface{}) error {
+	return tree.bind(ptr, "")
+}
+
+// bind registers the fields of the struct ptr points to as figs whose names start with keyPrefix
+func (tree *figTree) bind(ptr interface{}, keyPrefix string) error {
+	rv := reflect.ValueOf(ptr)
+	if rv.Kind() != reflect.Pointer || rv.IsNil() || rv.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("bind requires a non-nil pointer to a struct ; got %T", ptr)
+	}
+	tree.mu.RLock()
+	watching := tree.watcher != nil
+	tree.mu.RUnlock()
+	if watching {
+		return errors.New("bind cannot follow Watch ; follow the figs through Mutations or Fig[T] instead")
+	}
+	err := errors.Join(tree.bindStruct(rv.Elem(), "", keyPrefix)...)
+	tree.syncBindings()
+	return err
+}
+
+// bindStruct registers each exported field of rv and returns an UnmarshalError for each field that cannot be bound
+func (tree *figTree) bindStruct(rv reflect.Value, fieldPrefix, keyPrefix string) []error {
+	var errs []error
+	rt := rv.Type()
+	for i := 0; i < rt.NumField(); i++ {
+		sf := rt.Field(i)
+		if !sf.IsExported() {
+			continue
+		}
+		key, ok := figKeyOf(sf)
+		if !ok {
+			continue
+		}
+		field := fieldPrefix + sf.Name
+		key = keyPrefix + key
+		fv := rv.Field(i)
+		if sf.Type.Kind() == reflect.Pointer && sf.Type.Elem().Kind() == reflect.Struct {
+			if fv.IsNil() {
+				fv.Set(reflect.New(sf.Type.Elem()))
+			}
+			fv = fv.Elem()
+		}
+		if fv.Kind() == reflect.Struct && fv.Type() != durationType {
+			errs = append(errs, tree.bindStruct(fv, field+".", key+".")...)
+			continue
+		}
+		errs = append(errs, tree.bindField(fv, sf, field, key)...)
+	}
+	return errs
+}
+
+// bindField registers the fig for a single struct field with its aliases, rules and validators
+func (tree *figTree) bindField(fv reflect.Value, sf reflect.StructField, field, key string) []error {
+	mut := mutagenesisOfType(sf.Type)
+	if mut == "" {
+		return []error{UnmarshalError{field, key, "", fmt.Errorf("unsupported field type %s", sf.Type)}}
+	}
+	value := canonicalOf(fv, mut)
+	if def, ok := sf.Tag.Lookup(TagDefault); ok {
+		converted, err := toMutagenesis(mut, def)
+		if err != nil {
+			return []error{UnmarshalError{field, key, "", fmt.Errorf("invalid default %q: %w", def, err)}}
+		}
+		value = converted
+	}
+	usage := sf.Tag.Get(TagUsage)
+	switch mut {
+	case tString:
+		tree.NewString(key, value.(string), usage)
+	case tBool:
+		tree.NewBool(key, value.(bool), usage)
+	case tInt:
+		tree.NewInt(key, value.(int), usage)
+	case tInt64:
+		tree.NewInt64(key, value.(int64), usage)
+	case tFloat64:
+		tree.NewFloat64(key, value.(float64), usage)
+	case tDuration:
+		tree.NewDuration(key, value.(time.Duration), usage)
+	case tList:
+		tree.NewList(key, value.([]string), usage)
+	case tMap:
+		tree.NewMap(key, value.(map[string]string), usage)
+	}
+	var errs []error
+	for _, alias := range strings.Split(sf.Tag.Get(TagAlias), ",") {
+		if alias = strings.TrimSpace(alias); alias != "" {
+			tree.WithAlias(key, alias)
+		}
+	}
+	for _, name := range strings.Split(sf.Tag.Get(TagRules), "|") {
+		if name = strings.TrimSpace(name); name == "" {
+			continue
+		}
+		rule, err := RuleFromName(name)
+		if err != nil {
+			errs = append(errs, UnmarshalError{field, key, name, err})
+			continue
+		}
+		tree.WithRule(key, rule)
+	}
+	for _, token := range strings.Split(sf.Tag.Get(TagAssure), "|") {
+		if token = strings.TrimSpace(token); token == "" {
+			continue
+		}
+		validator, err := AssureFromToken(mut, token)
+		if err != nil {
+			errs = append(errs, UnmarshalError{field, key, token, err})
+			continue
+		}
+		tree.WithValidator(key, validator)
+	}
+	tree.mu.Lock()
+	if tree.bindings == nil {
+		tree.bindings = make(map[string][]figBinding)
+	}
+	tree.bindings[key] = append(tree.bindings[key], figBinding{field: fv, mutagenesis: mut})
+	tree.mu.Unlock()
+	return errs
+}
+
+// syncBindings copies the value of every bound fig into its struct fields
+func (tree *figTree) syncBindings() {
+	tree.mu.Lock()
+	defer tree.mu.Unlock()
+	for name := range tree.bindings {
+		tree.syncBinding(name)
+	}
+}
+
+// syncBinding requires the figTree.mu to be locked and copies the value of name into its bound struct fields
+func (tree *figTree) syncBinding(name string) {
+	bindings, ok := tree.bindings[name]
+	if !ok {
+		return
+	}
+	value, err := tree.from(name)
+	if err != nil || value == nil {
+		return
+	}
+	for _, binding := range bindings {
+		_ = assignField(binding.field, binding.mutagenesis, value.Value)
+	}
+}
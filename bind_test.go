@@ -0,0 +1,151 @@
+package figtree
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type bindServer struct {
+	Port    int           `fig:"port" default:"8080" usage:"listen port" alias:"p" assure:"inRange=1024,65535"`
+	Secret  string        `fig:"secret" default:"s3cr3t" usage:"signing secret" rules:"preventChange" assure:"notEmpty"`
+	Timeout time.Duration `fig:"timeout" default:"30s" usage:"request timeout"`
+}
+
+type bindConfig struct {
+	Name    string            `fig:"name" usage:"app name"`
+	Debug   bool              `fig:"debug" usage:"debug mode"`
+	Ratio   float64           `fig:"ratio" default:"0.25" usage:"ratio"`
+	Hosts   []string          `fig:"hosts" default:"a,b" usage:"hosts"`
+	Labels  map[string]string `fig:"labels" default:"env=dev" usage:"labels"`
+	Server  bindServer        `fig:"server"`
+	Ignored string            `fig:"-"`
+}
+
+func TestTree_Bind(t *testing.T) {
+	os.Args = []string{os.Args[0], "-server.port", "9090"}
+	cfg := bindConfig{Name: "figtree"}
+	figs := With(Options{Germinate: true, IgnoreEnvironment: true})
+	assert.NoError(t, figs.Bind(&cfg))
+	assert.Equal(t, "figtree", *figs.String("name"))
+	assert.Equal(t, 8080, cfg.Server.Port)
+	assert.Equal(t, []string{"a", "b"}, cfg.Hosts)
+	assert.Equal(t, map[string]string{"env": "dev"}, cfg.Labels)
+	assert.Contains(t, figs.UsageString(), "listen port")
+
+	assert.NoError(t, figs.Parse())
+	assert.Equal(t, 9090, cfg.Server.Port)
+	assert.Equal(t, 30*time.Second, cfg.Server.Timeout)
+	assert.Equal(t, 0.25, cfg.Ratio)
+
+	figs.StoreInt("p", 2048)
+	assert.Equal(t, 2048, cfg.Server.Port)
+	figs.StoreBool("debug", true)
+	assert.True(t, cfg.Debug)
+	figs.StoreString("server.secret", "changed")
+	assert.Equal(t, "s3cr3t", cfg.Server.Secret)
+
+	figs.StoreInt("server.port", 80)
+	assert.Error(t, figs.Reload())
+	assert.Equal(t, 80, cfg.Server.Port)
+	os.Args = []string{os.Args[0]}
+}
+
+func TestTree_Bind_LoadFile(t *testing.T) {
+	os.Args = []string{os.Args[0]}
+	path := filepath.Join(t.TempDir(), "config.json")
+	assert.NoError(t, os.WriteFile(path, []byte(`{"name":"loaded","server.port":4000}`), 0644))
+
+	var cfg bindConfig
+	figs := With(Options{Germinate: true, IgnoreEnvironment: true})
+	assert.NoError(t, figs.Bind(&cfg))
+	assert.NoError(t, figs.LoadFile(path))
+	assert.Equal(t, "loaded", cfg.Name)
+	assert.Equal(t, 4000, cfg.Server.Port)
+}
+
+func TestTree_Bind_Watch(t *testing.T) {
+	os.Args = []string{os.Args[0]}
+	path := filepath.Join(t.TempDir(), "config.json")
+	assert.NoError(t, os.WriteFile(path, []byte(`{"name":"loaded"}`), 0644))
+
+	var cfg bindConfig
+	figs := With(Options{Watch: true, WatchInterval: 5 * time.Millisecond, Germinate: true, IgnoreEnvironment: true})
+	assert.NoError(t, figs.Bind(&cfg))
+	err := figs.LoadFile(path)
+	var failure ErrLoadFailure
+	assert.True(t, errors.As(err, &failure), "Options.Watch refuses bound structs")
+	assert.Equal(t, "watch", failure.What)
+	assert.Equal(t, "loaded", cfg.Name)
+	assert.Error(t, figs.Watch(context.Background()))
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for i := 0; i < 20; i++ {
+			_ = cfg.Name // an application goroutine reading the struct without a lock
+			time.Sleep(time.Millisecond)
+		}
+	}()
+	assert.NoError(t, os.WriteFile(path, []byte(`{"name":"reloaded"}`), 0644))
+	<-done
+	assert.Equal(t, "loaded", cfg.Name, "nothing hot reloads into the struct")
+
+	var other bindServer
+	watched := With(Options{WatchInterval: 5 * time.Millisecond, Germinate: true, IgnoreEnvironment: true})
+	watched.NewString("name", "", "name")
+	assert.NoError(t, watched.LoadFile(path))
+	assert.NoError(t, watched.Watch(context.Background()))
+	defer watched.StopWatching()
+	assert.Error(t, watched.Bind(&other), "Bind refuses a tree that is watching")
+}
+
+func TestTree_Bind_NamedElements(t *testing.T) {
+	os.Args = []string{os.Args[0]}
+	figs := With(Options{Germinate: true, IgnoreEnvironment: true})
+	cfg := struct {
+		Tags   []label         `fig:"tags" assure:"notEmpty"`
+		Labels map[label]label `fig:"labels"`
+	}{Tags: []label{"web"}, Labels: map[label]label{"env": "dev"}}
+	assert.NotPanics(t, func() { assert.NoError(t, figs.Bind(&cfg)) })
+	assert.Equal(t, []string{"web"}, *figs.List("tags"))
+	assert.Equal(t, map[string]string{"env": "dev"}, *figs.Map("labels"))
+	assert.NoError(t, figs.ParseArgs([]string{"-tags", "api", "-labels", "env=prod"}))
+	assert.Equal(t, []label{"api"}, cfg.Tags)
+	assert.Equal(t, map[label]label{"env": "prod"}, cfg.Labels)
+}
+
+func TestTree_Bind_Errors(t *testing.T) {
+	os.Args = []string{os.Args[0]}
+	figs := With(Options{Germinate: true, IgnoreEnvironment: true})
+	assert.Error(t, figs.Bind(bindConfig{}))
+
+	var bad struct {
+		Port    int       `fig:"port" default:"eighty"`
+		Rule    string    `fig:"rule" rules:"bogus"`
+		Token   string    `fig:"token" assure:"inRange=1,2"`
+		Channel chan bool `fig:"channel"`
+	}
+	err := figs.Bind(&bad)
+	assert.Error(t, err)
+	assert.ErrorContains(t, err, "invalid default")
+	assert.ErrorContains(t, err, "unknown rule")
+	assert.ErrorContains(t, err, "unknown assure token")
+	assert.ErrorContains(t, err, "unsupported field type")
+}
+
+func TestRuleFromName(t *testing.T) {
+	rule, err := RuleFromName("preventChange")
+	assert.NoError(t, err)
+	assert.Equal(t, RulePreventChange, rule)
+	rule, err = RuleFromName("RuleNoEnv")
+	assert.NoError(t, err)
+	assert.Equal(t, RuleNoEnv, rule)
+	_, err = RuleFromName("bogus")
+	assert.Error(t, err)
+}
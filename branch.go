@@ -0,0 +1,631 @@
+package figtree
+
+import (
+	"context"
+	"crypto/ed25519"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"time"
+)
+
+// figBranch is a Branch that stores its figs on the root figTree under its path like db.host
+type figBranch struct {
+	tree  *figTree
+	path  string
+	rules []RuleKind
+}
+
+// NewBranch registers a Branch whose figs are stored under name and returns it
+//
+// Example:
+//
+//	figs := figtree.Grow()
+//	db := figs.NewBranch("db")
+//	db.NewString("host", "localhost", "database host")
+//	db.WithValidator("host", figtree.AssureStringNotEmpty)
+//	err := figs.Load() // -db.host, DB_HOST, {"db": {"host": "..."}} or [db] host = ...
+//	host := *db.String("host") // same as *figs.String("db.host")
+//
+// Calling NewBranch again with the same name returns the existing Branch. An empty name or a name that is already a
+// fig is recorded in Problems and returns a Branch that is not registered, so its figs still land on the figTree.
+func (tree *figTree) NewBranch(name string) Branch {
+	tree.mu.Lock()
+	defer tree.mu.Unlock()
+	path := strings.Trim(strings.ToLower(name), ".")
+	if path == "" {
+		tree.problems = append(tree.problems, fmt.Errorf("NewBranch: invalid branch name '%s'", name))
+		return &figBranch{tree: tree, rules: make([]RuleKind, 0)}
+	}
+	if branch, exists := tree.branches[path]; exists {
+		return branch
+	}
+	if _, exists := tree.figs[path]; exists {
+		tree.problems = append(tree.problems, fmt.Errorf("NewBranch: branch '%s' conflicts with existing fig name", path))
+		return &figBranch{tree: tree, path: path, rules: make([]RuleKind, 0)}
+	}
+	branch := &figBranch{tree: tree, path: path, rules: make([]RuleKind, 0)}
+	tree.branches[path] = branch
+	return branch
+}
+
+// Branch returns the Branch registered by NewBranch or nil
+func (tree *figTree) Branch(name string) Branch {
+	tree.mu.RLock()
+	defer tree.mu.RUnlock()
+	branch, exists := tree.branches[strings.Trim(strings.ToLower(name), ".")]
+	if !exists {
+		return nil
+	}
+	return branch
+}
+
+// branchRules requires the figTree.mu to be locked and returns the rules of every Branch that contains name
+func (tree *figTree) branchRules(name string) []RuleKind {
+	rules := make([]RuleKind, 0)
+	for path, branch := range tree.branches {
+		if strings.HasPrefix(name, path+".") {
+			rules = append(rules, branch.rules...)
+		}
+	}
+	return rules
+}
+
+// branchOf requires the figTree.mu to be locked and returns the path of the deepest Branch that contains name
+func (tree *figTree) branchOf(name string) string {
+	owner := ""
+	for path := range tree.branches {
+		if strings.HasPrefix(name, path+".") && len(path) > len(owner) {
+			owner = path
+		}
+	}
+	return owner
+}
+
+// isBranchPath requires the figTree.mu to be locked and reports whether path is a Branch or prefixes a fig name
+func (tree *figTree) isBranchPath(path string) bool {
+	if _, exists := tree.branches[path]; exists {
+		return true
+	}
+	for name := range tree.figs {
+		if strings.HasPrefix(name, path+".") {
+			return true
+		}
+	}
+	return false
+}
+
+// flattenBranches requires the figTree.mu to be locked and turns nested objects of branches into dotted keys
+func (tree *figTree) flattenBranches(data map[string]interface{}) map[string]interface{} {
+	flat := make(map[string]interface{}, len(data))
+	var flatten func(prefix string, data map[string]interface{})
+	flatten = func(prefix string, data map[string]interface{}) {
+		for key, value := range data {
+			name := prefix + strings.ToLower(key)
+			if nested, ok := value.(map[string]interface{}); ok && tree.isBranchPath(name) {
+				flatten(name+".", nested)
+				continue
+			}
+			flat[name] = value
+		}
+	}
+	flatten("", data)
+	return flat
+}
+
+// nestBranches requires the figTree.mu to be locked and turns dotted keys of branches into nested objects
+func (tree *figTree) nestBranches(properties map[string]interface{}) map[string]interface{} {
+	nested := make(map[string]interface{}, len(properties))
+	for name, value := range properties {
+		into := nested
+		rest := name
+		if path := tree.branchOf(name); path != "" {
+			for _, segment := range strings.Split(path, ".") {
+				child, ok := into[segment].(map[string]interface{})
+				if !ok {
+					child = make(map[string]interface{})
+					into[segment] = child
+				}
+				into = child
+			}
+			rest = strings.TrimPrefix(name, path+".")
+		}
+		into[rest] = value
+	}
+	return nested
+}
+
+// Path returns the dotted prefix of the Branch like db or db.replica
+func (b *figBranch) Path() string {
+	return b.path
+}
+
+// key returns the name of the fig on the root figTree
+func (b *figBranch) key(name string) string {
+	return b.prefix() + strings.ToLower(name)
+}
+
+// prefix returns the path of the Branch followed by a dot, or nothing for the Branch NewBranch returns for an empty name
+func (b *figBranch) prefix() string {
+	if b.path == "" {
+		return ""
+	}
+	return b.path + "."
+}
+
+// FigFlesh returns the Flesh of name inside the Branch
+func (b *figBranch) FigFlesh(name string) Flesh {
+	return b.tree.FigFlesh(b.key(name))
+}
+
+// ErrorFor returns the error attached to name inside the Branch
+func (b *figBranch) ErrorFor(name string) error {
+	return b.tree.ErrorFor(b.key(name))
+}
+
+// Usage prints the figs of the Branch
+func (b *figBranch) Usage() {
+	fmt.Println(b.UsageString())
+}
+
+// UsageString renders the figs of the Branch grouped by their nested branches
+func (b *figBranch) UsageString() string {
+	return b.tree.usageString(b.path)
+}
+
+// WithCallback registers a callback on name inside the Branch
+func (b *figBranch) WithCallback(name string, whenCallback CallbackWhen, runThis CallbackFunc) Plant {
+	b.tree.WithCallback(b.key(name), whenCallback, runThis)
+	return b
+}
+
+// WithAlias registers alias for name inside the Branch so -db.h resolves to -db.host
+func (b *figBranch) WithAlias(name, alias string) Plant {
+	b.tree.WithAlias(b.key(name), b.key(alias))
+	return b
+}
+
+// WithRule attaches a RuleKind to name inside the Branch
+func (b *figBranch) WithRule(name string, rule RuleKind) Plant {
+	b.tree.WithRule(b.key(name), rule)
+	return b
+}
+
+// WithEnv replaces the environment variable names of name inside the Branch
+func (b *figBranch) WithEnv(name string, envNames ...string) Plant {
+	b.tree.WithEnv(b.key(name), envNames...)
+	return b
+}
+
+// WithTreeRule attaches a RuleKind to every fig inside the Branch including figs registered later
+func (b *figBranch) WithTreeRule(rule RuleKind) Plant {
+	b.tree.mu.Lock()
+	defer b.tree.mu.Unlock()
+	b.rules = append(b.rules, rule)
+	for name, fruit := range b.tree.figs {
+		if fruit != nil && strings.HasPrefix(name, b.prefix()) {
+			fruit.Rules = append(fruit.Rules, rule)
+		}
+	}
+	return b
+}
+
+// WithValidator binds a validator to name inside the Branch
+func (b *figBranch) WithValidator(name string, validator func(interface{}) error) Plant {
+	b.tree.WithValidator(b.key(name), validator)
+	return b
+}
+
+// WithValidators binds validators to name inside the Branch
+func (b *figBranch) WithValidators(name string, validators ...func(interface{}) error) Plant {
+	b.tree.WithValidators(b.key(name), validators...)
+	return b
+}
+
+// SaveTo saves the root figTree since a Branch is stored inside of it
+func (b *figBranch) SaveTo(path string) error {
+	return b.tree.SaveTo(path)
+}
+
+// SaveToSigned saves and signs the root figTree since a Branch is stored inside of it
+func (b *figBranch) SaveToSigned(path string, key ed25519.PrivateKey) error {
+	return b.tree.SaveToSigned(path, key)
+}
+
+// SaveToEncrypted saves the root figTree with the fields of the Branch sealed
+func (b *figBranch) SaveToEncrypted(path string, fields ...string) error {
+	keys := make([]string, len(fields))
+	for i, field := range fields {
+		keys[i] = b.key(field)
+	}
+	return b.tree.SaveToEncrypted(path, keys...)
+}
+
+// ReadFrom reads path into the root figTree since a Branch is stored inside of it
+func (b *figBranch) ReadFrom(path string) error {
+	return b.tree.ReadFrom(path)
+}
+
+// Parse parses the root figTree
+func (b *figBranch) Parse() error {
+	return b.tree.Parse()
+}
+
+// ParseArgs parses the root figTree with args
+func (b *figBranch) ParseArgs(args []string) error {
+	return b.tree.ParseArgs(args)
+}
+
+// ParseFile parses the root figTree with filename
+func (b *figBranch) ParseFile(filename string) error {
+	return b.tree.ParseFile(filename)
+}
+
+// Mutations returns the Mutations of the root figTree where Property is the full name like db.host
+func (b *figBranch) Mutations() <-chan Mutation {
+	return b.tree.Mutations()
+}
+
+// MutagenesisOfFig returns the Mutagenesis of name inside the Branch
+func (b *figBranch) MutagenesisOfFig(name string) Mutagenesis {
+	return b.tree.MutagenesisOfFig(b.key(name))
+}
+
+// MutagenesisOf returns the Mutagenesis of what
+func (b *figBranch) MutagenesisOf(what interface{}) Mutagenesis {
+	return b.tree.MutagenesisOf(what)
+}
+
+// Load loads the root figTree
+func (b *figBranch) Load() error {
+	return b.tree.Load()
+}
+
+// LoadWithArgs loads the root figTree with args
+func (b *figBranch) LoadWithArgs(args []string) error {
+	return b.tree.LoadWithArgs(args)
+}
+
+// LoadFile loads path into the root figTree
+func (b *figBranch) LoadFile(path string) error {
+	return b.tree.LoadFile(path)
+}
+
+// LoadFiles loads paths into the root figTree
+func (b *figBranch) LoadFiles(paths ...string) error {
+	return b.tree.LoadFiles(paths...)
+}
+
+// Reload reloads the root figTree
+func (b *figBranch) Reload() error {
+	return b.tree.Reload()
+}
+
+// Watch watches the config files of the root figTree
+func (b *figBranch) Watch(ctx context.Context) error {
+	return b.tree.Watch(ctx)
+}
+
+// StopWatching stops the watcher of the root figTree
+func (b *figBranch) StopWatching() {
+	b.tree.StopWatching()
+}
+
+// Unmarshal populates the struct ptr points to from the figs inside the Branch
+func (b *figBranch) Unmarshal(ptr interface{}) error {
+	return b.tree.unmarshal(ptr, b.prefix())
+}
+
+// Bind registers a fig inside the Branch for each field of the struct ptr points to
+func (b *figBranch) Bind(ptr interface{}) error {
+	return b.tree.bind(ptr, b.prefix())
+}
+
+// Define registers name inside the Branch with its validators, callbacks and rules
+func (b *figBranch) Define(name string, value interface{}, usage string, validators []FigValidatorFunc, callbacks []Callback, rules []RuleKind) Plant {
+	b.tree.Define(b.key(name), value, usage, validators, callbacks, rules)
+	return b
+}
+
+// NewArg registers the positional argument name inside the Branch
+func (b *figBranch) NewArg(name string, mut Mutagenesis, usage string) Plant {
+	b.tree.NewArg(b.key(name), mut, usage)
+	return b
+}
+
+// NewArgs registers the variadic positional argument name inside the Branch
+func (b *figBranch) NewArgs(name string, mut Mutagenesis, usage string) Plant {
+	b.tree.NewArgs(b.key(name), mut, usage)
+	return b
+}
+
+// Args returns the positional arguments of the root figTree
+func (b *figBranch) Args() []string {
+	return b.tree.Args()
+}
+
+// SourceOf returns the Provenance of name inside the Branch
+func (b *figBranch) SourceOf(name string) Provenance {
+	return b.tree.SourceOf(b.key(name))
+}
+
+// Explain renders the resolution of every fig of the root figTree
+func (b *figBranch) Explain() string {
+	return b.tree.Explain()
+}
+
+// Command registers the subcommand name on the root figTree
+func (b *figBranch) Command(name string, run func(sub Plant)) Plant {
+	return b.tree.Command(name, run)
+}
+
+// NewBranch registers a Branch nested inside this Branch like db.replica
+func (b *figBranch) NewBranch(name string) Branch {
+	return b.tree.NewBranch(b.key(name))
+}
+
+// Branch returns a Branch nested inside this Branch or nil
+func (b *figBranch) Branch(name string) Branch {
+	return b.tree.Branch(b.key(name))
+}
+
+// Problems returns the Problems of the root figTree
+func (b *figBranch) Problems() []error {
+	return b.tree.Problems()
+}
+
+// Recall resumes tracking on the root figTree
+func (b *figBranch) Recall() {
+	b.tree.Recall()
+}
+
+// Curse locks the root figTree from changes
+func (b *figBranch) Curse() {
+	b.tree.Curse()
+}
+
+// Int returns a pointer to the int of name inside the Branch
+func (b *figBranch) Int(name string) *int {
+	return b.tree.Int(b.key(name))
+}
+
+// NewInt registers the int name inside the Branch
+func (b *figBranch) NewInt(name string, value int, usage string) Plant {
+	b.tree.NewInt(b.key(name), value, usage)
+	return b
+}
+
+// StoreInt replaces the int of name inside the Branch
+func (b *figBranch) StoreInt(name string, value int) Plant {
+	b.tree.StoreInt(b.key(name), value)
+	return b
+}
+
+// Int64 returns a pointer to the int64 of name inside the Branch
+func (b *figBranch) Int64(name string) *int64 {
+	return b.tree.Int64(b.key(name))
+}
+
+// NewInt64 registers the int64 name inside the Branch
+func (b *figBranch) NewInt64(name string, value int64, usage string) Plant {
+	b.tree.NewInt64(b.key(name), value, usage)
+	return b
+}
+
+// StoreInt64 replaces the int64 of name inside the Branch
+func (b *figBranch) StoreInt64(name string, value int64) Plant {
+	b.tree.StoreInt64(b.key(name), value)
+	return b
+}
+
+// Float64 returns a pointer to the float64 of name inside the Branch
+func (b *figBranch) Float64(name string) *float64 {
+	return b.tree.Float64(b.key(name))
+}
+
+// NewFloat64 registers the float64 name inside the Branch
+func (b *figBranch) NewFloat64(name string, value float64, usage string) Plant {
+	b.tree.NewFloat64(b.key(name), value, usage)
+	return b
+}
+
+// StoreFloat64 replaces the float64 of name inside the Branch
+func (b *figBranch) StoreFloat64(name string, value float64) Plant {
+	b.tree.StoreFloat64(b.key(name), value)
+	return b
+}
+
+// String returns a pointer to the string of name inside the Branch
+func (b *figBranch) String(name string) *string {
+	return b.tree.String(b.key(name))
+}
+
+// NewString registers the string name inside the Branch
+func (b *figBranch) NewString(name, value, usage string) Plant {
+	b.tree.NewString(b.key(name), value, usage)
+	return b
+}
+
+// NewSecret registers the secret string name inside the Branch
+func (b *figBranch) NewSecret(name, usage string) Plant {
+	b.tree.NewSecret(b.key(name), usage)
+	return b
+}
+
+// StoreString replaces the string of name inside the Branch
+func (b *figBranch) StoreString(name, value string) Plant {
+	b.tree.StoreString(b.key(name), value)
+	return b
+}
+
+// Bool returns a pointer to the bool of name inside the Branch
+func (b *figBranch) Bool(name string) *bool {
+	return b.tree.Bool(b.key(name))
+}
+
+// NewBool registers the bool name inside the Branch
+func (b *figBranch) NewBool(name string, value bool, usage string) Plant {
+	b.tree.NewBool(b.key(name), value, usage)
+	return b
+}
+
+// StoreBool replaces the bool of name inside the Branch
+func (b *figBranch) StoreBool(name string, value bool) Plant {
+	b.tree.StoreBool(b.key(name), value)
+	return b
+}
+
+// Duration returns a pointer to the time.Duration of name inside the Branch
+func (b *figBranch) Duration(name string) *time.Duration {
+	return b.tree.Duration(b.key(name))
+}
+
+// NewDuration registers the time.Duration name inside the Branch
+func (b *figBranch) NewDuration(name string, value time.Duration, usage string) Plant {
+	b.tree.NewDuration(b.key(name), value, usage)
+	return b
+}
+
+// StoreDuration replaces the time.Duration of name inside the Branch
+func (b *figBranch) StoreDuration(name string, value time.Duration) Plant {
+	b.tree.StoreDuration(b.key(name), value)
+	return b
+}
+
+// UnitDuration returns a pointer to the time.Duration of name inside the Branch
+func (b *figBranch) UnitDuration(name string) *time.Duration {
+	return b.tree.UnitDuration(b.key(name))
+}
+
+// NewUnitDuration registers the time.Duration name inside the Branch
+func (b *figBranch) NewUnitDuration(name string, value, units time.Duration, usage string) Plant {
+	b.tree.NewUnitDuration(b.key(name), value, units, usage)
+	return b
+}
+
+// StoreUnitDuration replaces the time.Duration of name inside the Branch
+func (b *figBranch) StoreUnitDuration(name string, value, units time.Duration) Plant {
+	b.tree.StoreUnitDuration(b.key(name), value, units)
+	return b
+}
+
+// List returns a pointer to the list of name inside the Branch
+func (b *figBranch) List(name string) *[]string {
+	return b.tree.List(b.key(name))
+}
+
+// NewList registers the list name inside the Branch
+func (b *figBranch) NewList(name string, value []string, usage string) Plant {
+	b.tree.NewList(b.key(name), value, usage)
+	return b
+}
+
+// StoreList replaces the list of name inside the Branch
+func (b *figBranch) StoreList(name string, value []string) Plant {
+	b.tree.StoreList(b.key(name), value)
+	return b
+}
+
+// Map returns a pointer to the map of name inside the Branch
+func (b *figBranch) Map(name string) *map[string]string {
+	return b.tree.Map(b.key(name))
+}
+
+// NewMap registers the map name inside the Branch
+func (b *figBranch) NewMap(name string, value map[string]string, usage string) Plant {
+	b.tree.NewMap(b.key(name), value, usage)
+	return b
+}
+
+// MapKeys returns the keys of the map name inside the Branch
+func (b *figBranch) MapKeys(name string) []string {
+	return b.tree.MapKeys(b.key(name))
+}
+
+// StoreMap replaces the map of name inside the Branch
+func (b *figBranch) StoreMap(name string, value map[string]string) Plant {
+	b.tree.StoreMap(b.key(name), value)
+	return b
+}
+
+// File returns a pointer to the file path of name inside the Branch
+func (b *figBranch) File(name string) *string {
+	return b.tree.File(b.key(name))
+}
+
+// NewFile registers the file path name inside the Branch
+func (b *figBranch) NewFile(name, path, usage string) Plant {
+	b.tree.NewFile(b.key(name), path, usage)
+	return b
+}
+
+// StoreFile replaces the file path of name inside the Branch
+func (b *figBranch) StoreFile(name, path string) Plant {
+	b.tree.StoreFile(b.key(name), path)
+	return b
+}
+
+// FileContents reads the file of name inside the Branch
+func (b *figBranch) FileContents(name string) ([]byte, error) {
+	return b.tree.FileContents(b.key(name))
+}
+
+// FileHandler opens the file of name inside the Branch
+func (b *figBranch) FileHandler(name string) (*os.File, error) {
+	return b.tree.FileHandler(b.key(name))
+}
+
+// FileWriteContents writes contents to the file of name inside the Branch
+func (b *figBranch) FileWriteContents(name string, contents []byte) error {
+	return b.tree.FileWriteContents(b.key(name), contents)
+}
+
+// Directory returns a pointer to the directory path of name inside the Branch
+func (b *figBranch) Directory(name string) *string {
+	return b.tree.Directory(b.key(name))
+}
+
+// NewDirectory registers the directory path name inside the Branch
+func (b *figBranch) NewDirectory(name, path, usage string) Plant {
+	b.tree.NewDirectory(b.key(name), path, usage)
+	return b
+}
+
+// StoreDirectory replaces the directory path of name inside the Branch
+func (b *figBranch) StoreDirectory(name, path string) Plant {
+	b.tree.StoreDirectory(b.key(name), path)
+	return b
+}
+
+// DirectoryFlushAll removes everything inside the directory of name inside the Branch
+func (b *figBranch) DirectoryFlushAll(name string) error {
+	return b.tree.DirectoryFlushAll(b.key(name))
+}
+
+// Semaphore returns the Sema of name inside the Branch
+func (b *figBranch) Semaphore(name string) Sema {
+	return b.tree.Semaphore(b.key(name))
+}
+
+// NewSemaphore registers the Semaphore name with limit slots inside the Branch
+func (b *figBranch) NewSemaphore(name string, limit int, usage string) Plant {
+	b.tree.NewSemaphore(b.key(name), limit, usage)
+	return b
+}
+
+// StoreSemaphore resizes the Semaphore of name inside the Branch
+func (b *figBranch) StoreSemaphore(name string, limit int) Plant {
+	b.tree.StoreSemaphore(b.key(name), limit)
+	return b
+}
+
+// sortedBranchPaths requires the figTree.mu to be locked and returns the registered branch paths in order
+func (tree *figTree) sortedBranchPaths() []string {
+	paths := make([]string, 0, len(tree.branches))
+	for path := range tree.branches {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+	return paths
+}
@@ -0,0 +1,161 @@
+package figtree
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTree_NewBranch(t *testing.T) {
+	os.Args = []string{os.Args[0], "-db.port", "6543"}
+	figs := With(Options{Germinate: true, Tracking: true, Harvest: 10})
+	db := figs.NewBranch("db")
+	assert.NotNil(t, db)
+	assert.Equal(t, "db", db.Path())
+	assert.Equal(t, db, figs.Branch("DB"))
+	assert.Equal(t, db, figs.NewBranch("db"))
+	assert.Nil(t, figs.Branch("cache"))
+
+	db.NewString("host", "localhost", "database host").
+		WithValidator("host", AssureStringNotEmpty).
+		NewInt("port", 5432, "database port")
+	db.WithAlias("host", "h")
+	replica := db.NewBranch("replica")
+	assert.Equal(t, "db.replica", replica.Path())
+	replica.NewString("host", "replica.local", "replica host")
+
+	t.Setenv("DB_HOST", "db.example.com")
+	assert.NoError(t, figs.Parse())
+	assert.Equal(t, "db.example.com", *db.String("host"))
+	assert.Equal(t, "db.example.com", *figs.String("db.host"))
+	assert.Equal(t, "db.example.com", *db.String("h"))
+	assert.Equal(t, 6543, *db.Int("port"))
+	assert.Equal(t, "replica.local", *replica.String("host"))
+
+	db.StoreString("host", "other.example.com")
+	var mutation Mutation
+	for mutation = range figs.Mutations() {
+		if mutation.Way == "StoreString" {
+			break
+		}
+	}
+	assert.Equal(t, "db.host", mutation.Property)
+	assert.Equal(t, "other.example.com", mutation.New)
+
+	figs.NewBranch("").NewString("region", "us-east", "region")
+	assert.Equal(t, "us-east", *figs.String("region"), "an invalid Branch still registers its figs")
+	figs.NewString("cache", "", "cache")
+	figs.NewBranch("cache").NewInt("ttl", 60, "cache ttl")
+	assert.Equal(t, 60, *figs.Int("cache.ttl"))
+	assert.Nil(t, figs.Branch("cache"), "the conflicting Branch is not registered")
+	assert.Len(t, figs.Problems(), 2)
+	os.Args = []string{os.Args[0]}
+}
+
+func TestTree_Branch_Rules(t *testing.T) {
+	os.Args = []string{os.Args[0]}
+	figs := With(Options{Germinate: true, IgnoreEnvironment: true})
+	secrets := figs.NewBranch("secrets")
+	secrets.NewString("token", "abc", "api token")
+	secrets.WithTreeRule(RulePreventChange)
+	secrets.NewString("key", "def", "signing key")
+	figs.NewString("name", "app", "app name")
+	assert.NoError(t, figs.Parse())
+
+	secrets.StoreString("token", "changed")
+	secrets.StoreString("key", "changed")
+	figs.StoreString("name", "changed")
+	assert.Equal(t, "abc", *secrets.String("token"))
+	assert.Equal(t, "def", *secrets.String("key"))
+	assert.Equal(t, "changed", *figs.String("name"))
+}
+
+func TestTree_Branch_Files(t *testing.T) {
+	os.Args = []string{os.Args[0]}
+	dir := t.TempDir()
+	tests := map[string]string{
+		"config.json": `{"name": "app", "db": {"host": "json.local", "replica": {"host": "json.replica"}}}`,
+		"config.yaml": "name: app\ndb:\n  host: yaml.local\n  replica:\n    host: yaml.replica\n",
+		"config.ini":  "name = app\n[db]\nhost = ini.local\n[db.replica]\nhost = ini.replica\n",
+		"config.toml": "name = \"app\"\n[db]\nhost = \"toml.local\"\n[db.replica]\nhost = \"toml.replica\"\n",
+	}
+	for file, contents := range tests {
+		t.Run(file, func(t *testing.T) {
+			ext := strings.TrimPrefix(filepath.Ext(file), ".")
+			path := filepath.Join(dir, file)
+			assert.NoError(t, os.WriteFile(path, []byte(contents), 0644))
+
+			figs := With(Options{Germinate: true, IgnoreEnvironment: true})
+			figs.NewString("name", "", "name")
+			db := figs.NewBranch("db")
+			db.NewString("host", "", "database host")
+			db.NewBranch("replica").NewString("host", "", "replica host")
+			assert.NoError(t, figs.ReadFrom(path))
+			assert.Equal(t, "app", *figs.String("name"))
+			assert.Equal(t, ext+".local", *db.String("host"))
+			assert.Equal(t, ext+".replica", *figs.String("db.replica.host"))
+
+			saved := filepath.Join(dir, "saved."+ext)
+			assert.NoError(t, figs.SaveTo(saved))
+			figs2 := With(Options{Germinate: true, IgnoreEnvironment: true})
+			figs2.NewString("name", "", "name")
+			db2 := figs2.NewBranch("db")
+			db2.NewString("host", "", "database host")
+			db2.NewBranch("replica").NewString("host", "", "replica host")
+			assert.NoError(t, figs2.ReadFrom(saved))
+			assert.Equal(t, ext+".local", *db2.String("host"))
+			assert.Equal(t, ext+".replica", *figs2.String("db.replica.host"))
+		})
+	}
+
+	figs := With(Options{Germinate: true, IgnoreEnvironment: true})
+	figs.NewBranch("db").NewString("host", "localhost", "database host")
+	saved := filepath.Join(dir, "nested.json")
+	assert.NoError(t, figs.SaveTo(saved))
+	data, err := os.ReadFile(saved)
+	assert.NoError(t, err)
+	var nested map[string]map[string]interface{}
+	assert.NoError(t, json.Unmarshal(data, &nested))
+	assert.Equal(t, "localhost", nested["db"]["host"])
+}
+
+func TestTree_Branch_Usage(t *testing.T) {
+	os.Args = []string{os.Args[0]}
+	figs := With(Options{Germinate: true, IgnoreEnvironment: true})
+	figs.NewString("name", "app", "app name")
+	db := figs.NewBranch("db")
+	db.NewString("host", "localhost", "database host")
+
+	usage := figs.UsageString()
+	assert.Contains(t, usage, "\n db:\n")
+	assert.Less(t, strings.Index(usage, "-name"), strings.Index(usage, " db:"))
+	assert.Less(t, strings.Index(usage, " db:"), strings.Index(usage, "-db.host"))
+
+	usage = db.UsageString()
+	assert.Contains(t, usage, "-db.host")
+	assert.NotContains(t, usage, "-name")
+}
+
+func TestTree_Branch_Bind(t *testing.T) {
+	os.Args = []string{os.Args[0]}
+	var cfg struct {
+		Host string `fig:"host" default:"localhost" usage:"database host"`
+		Port int    `fig:"port" default:"5432" usage:"database port"`
+	}
+	figs := With(Options{Germinate: true, IgnoreEnvironment: true})
+	db := figs.NewBranch("db")
+	assert.NoError(t, db.Bind(&cfg))
+	assert.NoError(t, figs.Parse())
+	db.StoreInt("port", 6543)
+	assert.Equal(t, 6543, cfg.Port)
+
+	var out struct {
+		Host string `fig:"host"`
+	}
+	assert.NoError(t, db.Unmarshal(&out))
+	assert.Equal(t, "localhost", out.Host)
+}
@@ -2,6 +2,7 @@ package figtree
 
 import (
 	"errors"
+	"fmt"
 )
 
 // WithCallback allows you to assign a slice of CallbackFunc to a figFruit attached to a figTree.
@@ -29,6 +30,7 @@ func (tree *figTree) WithCallback(name string, whenCallback CallbackWhen, runThi
 	name = tree.resolveName(name)
 	fruit, exists := tree.figs[name]
 	if !exists || fruit == nil {
+		tree.problems = append(tree.problems, fmt.Errorf("WithCallback: no fig named -%s", name))
 		return tree
 	}
 	if fruit.HasRule(RuleNoCallbacks) {
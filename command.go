@@ -0,0 +1,198 @@
+package figtree
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// figCommand is a subcommand registered by Command with its child figTree
+type figCommand struct {
+	name string
+	tree *figTree
+	run  func(sub Plant)
+}
+
+// Command registers the subcommand name and returns its child Plant for the figs only that command accepts
+//
+// Example:
+//
+//	figs := figtree.Grow()
+//	figs.NewBool("verbose", false, "verbose output")
+//	serve := figs.Command("serve", func(sub figtree.Plant) {
+//		log.Fatal(http.ListenAndServe(fmt.Sprintf(":%d", *sub.Int("port")), nil))
+//	})
+//	serve.NewInt("port", 8080, "listen port")
+//	figs.Command("migrate", func(sub figtree.Plant) { migrate(*sub.Bool("verbose")) })
+//	err := figs.Load() // app -verbose serve -port 9090
+//
+// Parse and Load stop reading flags at the first argument that names a command. Once the figs of the parent pass
+// validateAll, the child inherits every fig of the parent with its current value, parses the arguments after the
+// command token and then run receives the child. Inherited figs can be given before or after the token, and a value
+// given after it is stored back on the parent. Calling Command again with the same name returns the existing child.
+// An invalid name is recorded in Problems and returns the figTree itself so the chain that follows does not panic.
+func (tree *figTree) Command(name string, run func(sub Plant)) Plant {
+	tree.mu.Lock()
+	defer tree.mu.Unlock()
+	name = strings.ToLower(name)
+	if name == "" || strings.HasPrefix(name, "-") || strings.ContainsAny(name, " \t\n") {
+		tree.problems = append(tree.problems, fmt.Errorf("Command: invalid command name '%s'", name))
+		return tree
+	}
+	if cmd, exists := tree.commands[name]; exists {
+		return cmd.tree
+	}
+	child := With(Options{
+		Tracking:          tree.tracking,
+		Harvest:           tree.harvest,
+		Germinate:         tree.filterTests,
+		Pollinate:         tree.pollinate,
+		IgnoreEnvironment: tree.ignoreEnv,
+		EnvSource:         tree.env,
+		EnvPrefix:         tree.envPrefix,
+		EnvKey:            tree.envKey,
+		POSIX:             tree.posix,
+		Precedence:        tree.precedence,
+		Decrypter:         tree.decrypt,
+		KeyFile:           tree.keyFile,
+		TrustedKeys:       tree.trustedKeys,
+	}).(*figTree)
+	child.GlobalRules = append([]RuleKind(nil), tree.GlobalRules...)
+	child.parent = tree
+	child.command = strings.TrimSpace(tree.command + " " + name)
+	child.inherited = make(map[string]bool)
+	if tree.commands == nil {
+		tree.commands = make(map[string]*figCommand)
+	}
+	tree.commands[name] = &figCommand{name: name, tree: child, run: run}
+	return child
+}
+
+// selectCommand remembers the command named by the first argument left over by the flagSet and the arguments after it
+func (tree *figTree) selectCommand() error {
+	tree.mu.Lock()
+	defer tree.mu.Unlock()
+	tree.selected = nil
+	if len(tree.commands) == 0 || tree.flagSet.NArg() == 0 {
+		return nil
+	}
+	rest := tree.flagSet.Args()
+	cmd, ok := tree.commands[strings.ToLower(rest[0])]
+	if !ok {
+		return fmt.Errorf("unknown command '%s' ; expected one of %s", rest[0], strings.Join(tree.commandNames(), ", "))
+	}
+	tree.selected = cmd
+	tree.commandArgs = append([]string(nil), rest[1:]...)
+	return nil
+}
+
+// dispatch parses the arguments of the selected command on its child figTree and runs its callback
+func (tree *figTree) dispatch() error {
+	tree.mu.RLock()
+	cmd, args := tree.selected, tree.commandArgs
+	tree.mu.RUnlock()
+	if cmd == nil {
+		return nil
+	}
+	tree.ripen()
+	cmd.tree.inherit()
+	if err := cmd.tree.ParseArgs(args); err != nil {
+		return fmt.Errorf("command %s: %w", cmd.tree.command, err)
+	}
+	cmd.tree.bequeath()
+	if cmd.run != nil {
+		cmd.run(cmd.tree)
+	}
+	return nil
+}
+
+// inherit copies every fig of the parent figTree that the command does not define itself along with its current value
+func (tree *figTree) inherit() {
+	parent := tree.parent
+	parent.mu.RLock()
+	defer parent.mu.RUnlock()
+	tree.mu.Lock()
+	defer tree.mu.Unlock()
+	for name, fruit := range parent.figs {
+		if _, own := tree.figs[name]; (own && !tree.inherited[name]) || parent.isArg(name) {
+			continue
+		}
+		value, err := parent.from(name)
+		if err != nil || value == nil {
+			continue
+		}
+		raw, err := toMutagenesis(fruit.Mutagenesis, value.Value)
+		if err != nil {
+			continue
+		}
+		raw = cloneRaw(raw)
+		if existing, err := tree.from(name); err == nil && tree.inherited[name] {
+			_ = existing.Assign(raw)
+			continue
+		}
+		v := &Value{Value: raw, Mutagensis: fruit.Mutagenesis}
+		switch fruit.Mutagenesis {
+		case tList:
+			v.Value = ListFlag{values: raw.([]string)}
+		case tMap:
+			v.Value = MapFlag{values: raw.(map[string]string)}
+		}
+		tree.values.Store(name, v)
+		tree.flagSet.Var(v, name, fruit.usage)
+		tree.figs[name] = &figFruit{
+			name:        name,
+			usage:       fruit.usage,
+			Mutagenesis: fruit.Mutagenesis,
+			Mutations:   make([]Mutation, 0),
+			Validators:  append([]FigValidatorFunc(nil), fruit.Validators...),
+			Callbacks:   append([]Callback(nil), fruit.Callbacks...),
+			Rules:       append([]RuleKind(nil), fruit.Rules...),
+			envNames:    fruit.envNames,
+		}
+		tree.withered[name] = parent.withered[name]
+		tree.inherited[name] = true
+	}
+}
+
+// bequeath stores the inherited figs that the command arguments changed back on the parent figTree
+func (tree *figTree) bequeath() {
+	changed := make(map[string]interface{})
+	tree.mu.RLock()
+	for name := range tree.inherited {
+		value, err := tree.from(name)
+		if err != nil || value == nil {
+			continue
+		}
+		raw, err := toMutagenesis(tree.figs[name].Mutagenesis, value.Value)
+		if err == nil {
+			changed[name] = raw
+		}
+	}
+	tree.mu.RUnlock()
+	parent := tree.parent
+	for name, raw := range changed {
+		parent.mu.RLock()
+		fruit, ok := parent.figs[name]
+		value, err := parent.from(name)
+		same := true
+		if ok && err == nil {
+			old, _ := toMutagenesis(fruit.Mutagenesis, value.Value)
+			same = reflect.DeepEqual(old, raw)
+		}
+		parent.mu.RUnlock()
+		if !same {
+			_ = parent.storeFrom(fruit.Mutagenesis, name, raw, tree.SourceOf(name))
+		}
+	}
+}
+
+// commandNames requires the figTree.mu to be locked and returns the sorted names of the registered commands
+func (tree *figTree) commandNames() []string {
+	names := make([]string, 0, len(tree.commands))
+	for name := range tree.commands {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,113 @@
+package figtree
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTree_Command(t *testing.T) {
+	os.Args = []string{os.Args[0]}
+	figs := With(Options{Germinate: true, IgnoreEnvironment: true, Tracking: true, Harvest: 10})
+	figs.NewBool("verbose", false, "verbose output")
+	figs.NewString("env", "dev", "deployment environment")
+	figs.WithValidator("env", AssureStringNotEmpty)
+
+	var ran []string
+	var port int
+	var verbose bool
+	serve := figs.Command("serve", func(sub Plant) {
+		ran = append(ran, "serve")
+		port = *sub.Int("port")
+		verbose = *sub.Bool("verbose")
+	})
+	serve.NewInt("port", 8080, "listen port")
+	serve.WithValidator("port", AssureIntInRange(1, 65535))
+	figs.Command("migrate", func(sub Plant) { ran = append(ran, "migrate") })
+	assert.Equal(t, serve, figs.Command("SERVE", nil))
+
+	assert.NoError(t, figs.ParseArgs([]string{"-env", "prod", "serve", "-port", "9090", "-verbose"}))
+	assert.Equal(t, []string{"serve"}, ran)
+	assert.Equal(t, 9090, port)
+	assert.True(t, verbose)
+	assert.Equal(t, "prod", *serve.String("env"))
+	assert.True(t, *figs.Bool("verbose"))
+	assert.Empty(t, figs.MutagenesisOfFig("port"))
+
+	assert.NoError(t, figs.ParseArgs([]string{"migrate"}))
+	assert.Equal(t, []string{"serve", "migrate"}, ran)
+
+	assert.NoError(t, figs.ParseArgs([]string{"-env", "qa"}))
+	assert.Len(t, ran, 2)
+
+	assert.Error(t, figs.ParseArgs([]string{"deploy"}))
+	assert.Error(t, figs.ParseArgs([]string{"serve", "-port", "0"}))
+	assert.Error(t, figs.ParseArgs([]string{"-env", "", "serve"}))
+	assert.Len(t, ran, 2)
+
+	assert.Equal(t, figs, figs.Command("", nil))
+	assert.Equal(t, figs, figs.Command("-bad", nil))
+	assert.Len(t, figs.Problems(), 2)
+}
+
+func TestTree_Command_Load(t *testing.T) {
+	os.Args = []string{os.Args[0]}
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	assert.NoError(t, os.WriteFile(path, []byte("env: staging\n"), 0644))
+	figs := With(Options{Germinate: true, IgnoreEnvironment: true, ConfigFile: path})
+	figs.NewString("env", "dev", "deployment environment")
+	var env string
+	figs.Command("export", func(sub Plant) {
+		env = *sub.String("env")
+	}).NewString("format", "json", "export format")
+	assert.NoError(t, figs.LoadWithArgs([]string{"export", "-format", "csv"}))
+	assert.Equal(t, "staging", env)
+}
+
+func TestTree_Command_LoadFile(t *testing.T) {
+	os.Args = []string{os.Args[0]}
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	assert.NoError(t, os.WriteFile(path, []byte("env: staging\n"), 0644))
+	for name, load := range map[string]func(Plant) error{
+		"LoadFile":  func(figs Plant) error { return figs.LoadFile(path) },
+		"ParseFile": func(figs Plant) error { return figs.ParseFile(path) },
+	} {
+		figs := With(Options{Germinate: true, IgnoreEnvironment: true, Args: []string{"export", "-format", "csv"}})
+		figs.NewString("env", "dev", "deployment environment")
+		var env, format string
+		figs.Command("export", func(sub Plant) {
+			env, format = *sub.String("env"), *sub.String("format")
+		}).NewString("format", "json", "export format")
+		assert.NoError(t, load(figs), name)
+		assert.Equal(t, "staging", env, name)
+		assert.Equal(t, "csv", format, name)
+
+		figs = With(Options{Germinate: true, IgnoreEnvironment: true, Args: []string{"deploy"}})
+		figs.Command("export", nil)
+		assert.Error(t, load(figs), name)
+	}
+}
+
+func TestTree_Command_Usage(t *testing.T) {
+	os.Args = []string{os.Args[0]}
+	figs := With(Options{Germinate: true, IgnoreEnvironment: true})
+	figs.NewBool("verbose", false, "verbose output")
+	serve := figs.Command("serve", nil)
+	serve.NewInt("port", 8080, "listen port")
+	figs.Command("migrate", nil)
+
+	usage := figs.UsageString()
+	assert.Contains(t, usage, "[command]")
+	assert.Contains(t, usage, "\nCommands:\n   migrate\n   serve\n")
+	assert.NotContains(t, usage, "-port")
+
+	usage = serve.UsageString()
+	assert.Contains(t, usage, filepath.Base(os.Args[0])+" serve ")
+	assert.Contains(t, usage, " global flags:\n")
+	assert.Less(t, strings.Index(usage, "-port"), strings.Index(usage, " global flags:"))
+	assert.Less(t, strings.Index(usage, " global flags:"), strings.Index(usage, "-verbose"))
+	assert.NotContains(t, usage, "Commands:")
+}
@@ -0,0 +1,86 @@
+package figtree
+
+import (
+	"flag"
+	"strings"
+	"time"
+)
+
+// adoptCommandLine imports the flags registered on flag.CommandLine that are not yet figs of the tree
+//
+// Each flag becomes a fig typed from its flag.Getter value: bool, int, int64, float64 and time.Duration flags keep
+// their type, uint flags become an Int64 and every other flag.Value becomes a String. The current value of the flag
+// is the default of its fig and the flag itself is updated by ripen so variables from flag.String and friends follow
+// the tree. Flags named like an existing fig are recorded in Problems() by Define. With Options.Germinate the test.
+// flags of go test are left alone.
+func (tree *figTree) adoptCommandLine() {
+	if tree.adopted == nil {
+		return
+	}
+	type adoption struct {
+		f       *flag.Flag
+		value   interface{}
+		claimed bool
+	}
+	var pending []adoption
+	tree.mu.RLock()
+	flag.CommandLine.VisitAll(func(f *flag.Flag) {
+		name := strings.ToLower(f.Name)
+		if _, done := tree.adopted[name]; done {
+			return
+		}
+		if tree.filterTests && strings.HasPrefix(name, "test.") {
+			return
+		}
+		_, claimed := tree.figs[name]
+		pending = append(pending, adoption{f, adoptedValue(f.Value), claimed})
+	})
+	tree.mu.RUnlock()
+	for _, a := range pending {
+		name := strings.ToLower(a.f.Name)
+		tree.Define(name, a.value, a.f.Usage, nil, nil, nil)
+		tree.mu.Lock()
+		if a.claimed {
+			tree.adopted[name] = nil // the fig came first so the flag is not kept in sync
+		} else {
+			tree.adopted[name] = a.f
+		}
+		tree.mu.Unlock()
+	}
+}
+
+// adoptedValue returns the typed value of a flag.Value for Define
+func adoptedValue(v flag.Value) interface{} {
+	getter, ok := v.(flag.Getter)
+	if !ok {
+		if b, ok := v.(interface{ IsBoolFlag() bool }); ok && b.IsBoolFlag() {
+			return v.String() == "true"
+		}
+		return v.String()
+	}
+	switch val := getter.Get().(type) {
+	case string, bool, int, int64, float64, time.Duration:
+		return val
+	case uint:
+		return int64(val)
+	case uint64:
+		return int64(val)
+	default:
+		return v.String()
+	}
+}
+
+// syncAdopted requires the figTree.mu to be locked and assigns the value of name back to the flag.CommandLine flag it was adopted from
+func (tree *figTree) syncAdopted(name string) {
+	f := tree.adopted[name]
+	if f == nil {
+		return
+	}
+	value, err := tree.from(name)
+	if err != nil || value == nil {
+		return
+	}
+	if s := value.String(); s != f.Value.String() {
+		_ = f.Value.Set(s)
+	}
+}
@@ -0,0 +1,79 @@
+package figtree
+
+import (
+	"flag"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTree_OwnFlagSet(t *testing.T) {
+	os.Args = []string{os.Args[0]}
+	commandLine := flag.CommandLine
+	defer func() { flag.CommandLine = commandLine }()
+	flag.CommandLine = flag.NewFlagSet(os.Args[0], flag.ContinueOnError)
+	verbose := flag.Bool("verbose", false, "third-party flag")
+
+	one := With(Options{Germinate: true, IgnoreEnvironment: true})
+	one.NewInt("port", 8080, "listen port")
+	two := With(Options{Germinate: true, IgnoreEnvironment: true})
+	two.NewString("name", "app", "app name")
+	assert.NoError(t, one.ParseArgs([]string{"-port", "9090"}))
+	assert.NoError(t, two.ParseArgs([]string{"-name", "other"}))
+
+	assert.Equal(t, 9090, *one.Int("port"))
+	assert.Equal(t, "other", *two.String("name"))
+	assert.Nil(t, flag.CommandLine.Lookup("port"))
+	assert.Nil(t, flag.CommandLine.Lookup("name"))
+	assert.NotNil(t, flag.CommandLine.Lookup("verbose"))
+	assert.False(t, *verbose)
+	assert.Error(t, one.ParseArgs([]string{"-verbose"}))
+}
+
+func TestTree_AdoptCommandLine(t *testing.T) {
+	os.Args = []string{os.Args[0]}
+	commandLine := flag.CommandLine
+	defer func() { flag.CommandLine = commandLine }()
+	flag.CommandLine = flag.NewFlagSet(os.Args[0], flag.ContinueOnError)
+	verbose := flag.Bool("verbose", false, "verbose output")
+	host := flag.String("host", "localhost", "server host")
+	workers := flag.Int("workers", 2, "worker count")
+	size := flag.Uint("size", 7, "buffer size")
+	timeout := flag.Duration("timeout", time.Second, "request timeout")
+	flag.Float64("ratio", 0.5, "sample ratio")
+
+	figs := With(Options{Germinate: true, IgnoreEnvironment: true, AdoptCommandLine: true})
+	assert.Equal(t, tBool, figs.MutagenesisOfFig("verbose"))
+	assert.Equal(t, tString, figs.MutagenesisOfFig("host"))
+	assert.Equal(t, tInt, figs.MutagenesisOfFig("workers"))
+	assert.Equal(t, tInt64, figs.MutagenesisOfFig("size"))
+	assert.Equal(t, tDuration, figs.MutagenesisOfFig("timeout"))
+	assert.Equal(t, tFloat64, figs.MutagenesisOfFig("ratio"))
+	assert.Contains(t, figs.UsageString(), "worker count")
+
+	figs.WithValidator("workers", AssureIntInRange(1, 16))
+	assert.NoError(t, figs.ParseArgs([]string{"-verbose", "-host", "example.com", "-workers", "8", "-timeout", "3s"}))
+	assert.True(t, *figs.Bool("verbose"))
+	assert.Equal(t, "example.com", *figs.String("host"))
+	assert.Equal(t, 8, *figs.Int("workers"))
+	assert.Equal(t, 3*time.Second, *figs.Duration("timeout"))
+	assert.True(t, *verbose)
+	assert.Equal(t, "example.com", *host)
+	assert.Equal(t, 8, *workers)
+	assert.Equal(t, uint(7), *size)
+	assert.Equal(t, 3*time.Second, *timeout)
+
+	flag.Int("late", 1, "registered after With")
+	assert.NoError(t, figs.ParseArgs([]string{"-late", "5"}))
+	assert.Equal(t, 5, *figs.Int("late"))
+
+	figs.NewString("name", "fig", "app name")
+	name := flag.String("name", "flag", "claimed by a fig")
+	assert.NoError(t, figs.ParseArgs([]string{"-name", "changed"}))
+	assert.Equal(t, "changed", *figs.String("name"))
+	assert.Equal(t, "flag", *name)
+	assert.Len(t, figs.Problems(), 1)
+	assert.Error(t, figs.ParseArgs([]string{"-workers", "99"}))
+}
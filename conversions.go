@@ -299,3 +299,65 @@ func toStringMap(value interface{}) (map[string]string, error) {
 		return nil, ErrConversion{MutagenesisOf(value), tMap, value}
 	}
 }
+
+// toDuration returns an interface{} as a time.Duration or returns an error
+func toDuration(value interface{}) (time.Duration, error) {
+	switch v := value.(type) {
+	case *Value:
+		return toDuration(v.Value)
+	case *figFlesh:
+		return toDuration(v.AsIs())
+	case time.Duration:
+		return v, nil
+	case *time.Duration:
+		return *v, nil
+	case int64:
+		return time.Duration(v), nil
+	case *int64:
+		return time.Duration(*v), nil
+	case int:
+		return time.Duration(v), nil
+	case *int:
+		return time.Duration(*v), nil
+	case *string:
+		return toDuration(*v)
+	case string:
+		if d, err := time.ParseDuration(v); err == nil {
+			return d, nil
+		}
+		if d, err := ParseCustomDuration(v); err == nil {
+			return d, nil
+		}
+		i, err := strconv.ParseInt(v, 10, 64)
+		if err != nil {
+			return 0, ErrConversion{MutagenesisOf(value), tDuration, value}
+		}
+		return time.Duration(i), nil
+	default:
+		return 0, ErrConversion{MutagenesisOf(value), tDuration, value}
+	}
+}
+
+// toMutagenesis returns an interface{} converted into the Go type that a Mutagenesis stores or returns an error
+func toMutagenesis(mut Mutagenesis, value interface{}) (interface{}, error) {
+	switch mut {
+	case tString, tFile, tDirectory:
+		return toString(value)
+	case tBool:
+		return toBool(value)
+	case tInt, tSemaphore:
+		return toInt(value)
+	case tInt64:
+		return toInt64(value)
+	case tFloat64:
+		return toFloat64(value)
+	case tDuration, tUnitDuration:
+		return toDuration(value)
+	case tList:
+		return toStringSlice(value)
+	case tMap:
+		return toStringMap(value)
+	default:
+		return nil, ErrConversion{MutagenesisOf(value), mut, value}
+	}
+}
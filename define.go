@@ -0,0 +1,155 @@
+package figtree
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+)
+
+// callbackWhens lists every CallbackWhen that a Callback can be registered for
+var callbackWhens = []CallbackWhen{
+	CallbackAfterChange,
+	CallbackAfterRead,
+	CallbackAfterVerify,
+	CallbackBeforeChange,
+	CallbackBeforeRead,
+	CallbackBeforeVerify,
+}
+
+// Define registers name with its validators, callbacks and rules in one step and infers its Mutagenesis from value
+//
+// Example:
+//
+//	figs := figtree.Grow()
+//	figs.Define("port", 8080, "listen port",
+//		[]figtree.FigValidatorFunc{figtree.AssureIntInRange(1024, 65535)},
+//		[]figtree.Callback{{CallbackWhen: figtree.CallbackAfterChange, CallbackFunc: restart}},
+//		[]figtree.RuleKind{figtree.RulePreventChange})
+//	if problems := figs.Problems(); len(problems) > 0 {
+//		log.Fatal(errors.Join(problems...))
+//	}
+//
+// The Mutagenesis comes from MutagenesisOf(value), so value can be a string, bool, int, int64, float64,
+// time.Duration, []string or map[string]string. Nothing is registered when name already exists, the type
+// cannot be inferred, a validator or callback is nil, a CallbackWhen or RuleKind is unknown, or a rule
+// contradicts the validators or callbacks; every such misconfiguration is recorded in Problems().
+func (tree *figTree) Define(name string, value interface{}, usage string, validators []FigValidatorFunc, callbacks []Callback, rules []RuleKind) Plant {
+	tree.mu.Lock()
+	defer tree.mu.Unlock()
+	name = strings.ToLower(name)
+	mut, problems := tree.defineProblems(name, value, validators, callbacks, rules)
+	if len(problems) > 0 {
+		tree.problems = append(tree.problems, fmt.Errorf("Define: -%s: %w", name, errors.Join(problems...)))
+		return tree
+	}
+	raw := cloneRaw(derefValue(value))
+	v := &Value{Value: raw, Mutagensis: mut}
+	switch mut {
+	case tList:
+		v.Value = ListFlag{values: raw.([]string)}
+	case tMap:
+		v.Value = MapFlag{values: raw.(map[string]string)}
+	}
+	tree.values.Store(name, v)
+	tree.flagSet.Var(v, name, usage)
+	def := &figFruit{
+		name:        name,
+		usage:       usage,
+		Mutagenesis: mut,
+		Mutations:   make([]Mutation, 0),
+		Validators:  append(make([]FigValidatorFunc, 0, len(validators)), validators...),
+		Callbacks:   append(make([]Callback, 0, len(callbacks)), callbacks...),
+		Rules:       append(tree.branchRules(name), rules...),
+	}
+	tree.figs[name] = def
+	if _, exists := tree.withered[name]; !exists {
+		tree.withered[name] = witheredFig{
+			name: name,
+			Value: Value{
+				Value:      cloneRaw(raw),
+				Mutagensis: mut,
+			},
+			Mutagenesis: mut,
+		}
+	}
+	return tree
+}
+
+// defineProblems requires the figTree.mu to be locked and returns the Mutagenesis of value with every misconfiguration of a Define
+func (tree *figTree) defineProblems(name string, value interface{}, validators []FigValidatorFunc, callbacks []Callback, rules []RuleKind) (Mutagenesis, []error) {
+	var problems []error
+	if name == "" {
+		problems = append(problems, errors.New("name is empty"))
+	}
+	if _, exists := tree.figs[name]; exists {
+		problems = append(problems, fmt.Errorf("name '%s' already exists", name))
+	}
+	if _, exists := tree.aliases[name]; exists {
+		problems = append(problems, fmt.Errorf("name '%s' is already an alias", name))
+	}
+	if _, exists := tree.branches[name]; exists {
+		problems = append(problems, fmt.Errorf("name '%s' is already a branch", name))
+	}
+	if tree.flagSet != nil && tree.flagSet.Lookup(name) != nil && tree.figs[name] == nil {
+		problems = append(problems, fmt.Errorf("name '%s' conflicts with existing flag", name))
+	}
+	var mut Mutagenesis
+	switch raw := derefValue(value); raw.(type) {
+	case Value, flag.Value:
+		// a Value or flag.Value would be guessed from its String() ; Define needs a plain default
+	default:
+		mut = MutagenesisOf(raw)
+	}
+	switch mut {
+	case "":
+		problems = append(problems, fmt.Errorf("cannot infer Mutagenesis of %T", value))
+	case tList:
+		if tree.HasRule(RuleNoLists) {
+			problems = append(problems, errors.New("RuleNoLists forbids a List"))
+		}
+	case tMap:
+		if tree.HasRule(RuleNoMaps) {
+			problems = append(problems, errors.New("RuleNoMaps forbids a Map"))
+		}
+	}
+	for i, validator := range validators {
+		if validator == nil {
+			problems = append(problems, fmt.Errorf("validator %d is nil", i))
+		}
+	}
+	for i, callback := range callbacks {
+		if !slices.Contains(callbackWhens, callback.CallbackWhen) {
+			problems = append(problems, fmt.Errorf("callback %d has unknown CallbackWhen '%s'", i, callback.CallbackWhen))
+		}
+		if callback.CallbackFunc == nil {
+			problems = append(problems, fmt.Errorf("callback %d has a nil CallbackFunc", i))
+		}
+	}
+	for _, rule := range rules {
+		if !ruleKnown(rule) {
+			problems = append(problems, fmt.Errorf("unknown RuleKind %d", rule))
+		}
+	}
+	if slices.Contains(rules, RuleNoValidations) && len(validators) > 0 {
+		problems = append(problems, errors.New("RuleNoValidations would skip its validators"))
+	}
+	if slices.Contains(rules, RuleNoCallbacks) && len(callbacks) > 0 {
+		problems = append(problems, errors.New("RuleNoCallbacks would skip its callbacks"))
+	}
+	return mut, problems
+}
+
+// derefValue returns what value points to when it is a pointer, or nil when the pointer is nil
+func derefValue(value interface{}) interface{} {
+	rv := reflect.ValueOf(value)
+	if rv.Kind() != reflect.Pointer {
+		return value
+	}
+	if rv.IsNil() {
+		return nil
+	}
+	return rv.Elem().Interface()
+}
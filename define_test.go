@@ -0,0 +1,119 @@
+package figtree
+
+import (
+	"errors"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTree_Define(t *testing.T) {
+	os.Args = []string{os.Args[0], "-port", "9090"}
+	figs := With(Options{Germinate: true, IgnoreEnvironment: true})
+	changed := 0
+	figs.Define("port", 8080, "listen port",
+		[]FigValidatorFunc{AssureIntInRange(1024, 65535)},
+		[]Callback{{CallbackWhen: CallbackAfterChange, CallbackFunc: func(interface{}) error {
+			changed++
+			return nil
+		}}},
+		[]RuleKind{RuleNoEnv}).
+		Define("name", "app", "app name", nil, nil, []RuleKind{RulePreventChange}).
+		Define("debug", false, "debug mode", nil, nil, nil).
+		Define("ratio", 0.5, "ratio", nil, nil, nil).
+		Define("size", int64(64), "size", nil, nil, nil).
+		Define("timeout", 5*time.Second, "timeout", nil, nil, nil).
+		Define("hosts", []string{"a", "b"}, "hosts", nil, nil, nil).
+		Define("labels", map[string]string{"env": "dev"}, "labels", nil, nil, nil)
+	assert.Empty(t, figs.Problems())
+	assert.NoError(t, figs.Parse())
+
+	assert.Equal(t, tInt, figs.MutagenesisOfFig("port"))
+	assert.Equal(t, 9090, *figs.Int("port"))
+	assert.Equal(t, false, *figs.Bool("debug"))
+	assert.Equal(t, 0.5, *figs.Float64("ratio"))
+	assert.Equal(t, int64(64), *figs.Int64("size"))
+	assert.Equal(t, 5*time.Second, *figs.Duration("timeout"))
+	assert.Equal(t, []string{"a", "b"}, *figs.List("hosts"))
+	assert.Equal(t, map[string]string{"env": "dev"}, *figs.Map("labels"))
+	assert.Contains(t, figs.UsageString(), "listen port")
+
+	figs.StoreInt("port", 8081)
+	assert.Equal(t, 1, changed)
+	figs.StoreString("name", "changed")
+	assert.Equal(t, "app", *figs.String("name"))
+
+	os.Args = []string{os.Args[0], "-port", "80"}
+	figs = With(Options{Germinate: true, IgnoreEnvironment: true})
+	figs.Define("port", 8080, "listen port", []FigValidatorFunc{AssureIntInRange(1024, 65535)}, nil, nil)
+	assert.Error(t, figs.Parse())
+	os.Args = []string{os.Args[0]}
+}
+
+func TestTree_Define_Pointer(t *testing.T) {
+	os.Args = []string{os.Args[0]}
+	figs := With(Options{Germinate: true, IgnoreEnvironment: true})
+	host := "localhost"
+	figs.Define("host", &host, "host", nil, nil, nil)
+	var missing *string
+	figs.Define("missing", missing, "missing", nil, nil, nil)
+	assert.NoError(t, figs.Parse())
+	assert.Equal(t, "localhost", *figs.String("host"))
+	assert.Len(t, figs.Problems(), 1)
+}
+
+func TestTree_Define_Problems(t *testing.T) {
+	os.Args = []string{os.Args[0]}
+	tests := map[string]struct {
+		value      interface{}
+		validators []FigValidatorFunc
+		callbacks  []Callback
+		rules      []RuleKind
+		contains   string
+	}{
+		"duplicate":       {"x", nil, nil, nil, "already exists"},
+		"uninferable":     {struct{}{}, nil, nil, nil, "cannot infer Mutagenesis"},
+		"nil validator":   {"x", []FigValidatorFunc{nil}, nil, nil, "validator 0 is nil"},
+		"unknown when":    {"x", nil, []Callback{{CallbackWhen: "sometime", CallbackFunc: func(interface{}) error { return nil }}}, nil, "unknown CallbackWhen"},
+		"nil callback":    {"x", nil, []Callback{{CallbackWhen: CallbackAfterRead}}, nil, "nil CallbackFunc"},
+		"unknown rule":    {"x", nil, nil, []RuleKind{RuleUndefined}, "unknown RuleKind"},
+		"no validations":  {"x", []FigValidatorFunc{AssureStringNotEmpty}, nil, []RuleKind{RuleNoValidations}, "RuleNoValidations"},
+		"no callbacks":    {"x", nil, []Callback{{CallbackWhen: CallbackAfterRead, CallbackFunc: func(interface{}) error { return nil }}}, []RuleKind{RuleNoCallbacks}, "RuleNoCallbacks"},
+		"flag value type": {Value{Value: "x", Mutagensis: tString}, nil, nil, nil, "cannot infer Mutagenesis"},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			figs := With(Options{Germinate: true, IgnoreEnvironment: true})
+			figs.NewString("duplicate", "", "existing")
+			figs.Define(name, tt.value, "usage", tt.validators, tt.callbacks, tt.rules)
+			problems := figs.Problems()
+			if assert.Len(t, problems, 1) {
+				assert.ErrorContains(t, problems[0], tt.contains)
+			}
+			if name != "duplicate" {
+				assert.Empty(t, figs.MutagenesisOfFig(name))
+			}
+		})
+	}
+
+	figs := With(Options{Germinate: true, IgnoreEnvironment: true})
+	figs.WithTreeRule(RuleNoLists)
+	figs.Define("hosts", []string{"a"}, "hosts", nil, nil, nil)
+	assert.ErrorContains(t, errors.Join(figs.Problems()...), "RuleNoLists")
+	assert.Empty(t, figs.MutagenesisOfFig("hosts"))
+}
+
+func TestTree_WithRule_WithCallback_Missing(t *testing.T) {
+	os.Args = []string{os.Args[0]}
+	figs := With(Options{Germinate: true, IgnoreEnvironment: true})
+	figs.NewString("name", "app", "app name")
+	figs.WithRule("nmae", RulePreventChange)
+	figs.WithCallback("nmae", CallbackAfterRead, func(interface{}) error { return nil })
+	problems := figs.Problems()
+	if assert.Len(t, problems, 2) {
+		assert.ErrorContains(t, problems[0], "WithRule: no fig named -nmae")
+		assert.ErrorContains(t, problems[1], "WithCallback: no fig named -nmae")
+	}
+}
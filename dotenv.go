@@ -0,0 +1,251 @@
+package figtree
+
+import (
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// isDotenv reports whether path names a dotenv file like .env, .env.local or app.env
+func isDotenv(path string) bool {
+	base := strings.ToLower(filepath.Base(path))
+	return base == ".env" || strings.HasPrefix(base, ".env.") || filepath.Ext(base) == ".env"
+}
+
+// loadDotenv parses a dotenv file and assigns each key to the fig whose environment name it matches
+//
+// Keys match figs the way checkAndSetFromEnv looks them up, so DB_HOST sets db.host and PORT sets port, or
+// MYAPP_PORT with Options.EnvPrefix and the names given to WithEnv.
+// Keys without a fig are ignored like unrelated environment variables.
+func (tree *figTree) loadDotenv(path string, data []byte) error {
+	env, err := parseDotenv(data, tree.getEnv)
+	if err != nil {
+		return err
+	}
+	tree.mu.Lock()
+	defer tree.mu.Unlock()
+	values := make(map[string]interface{})
+	keys := make(map[string]string)
+	for name := range tree.figs {
+		for _, key := range tree.envNamesOf(name) {
+			if val, ok := env[key]; ok {
+				values[name] = val
+				keys[name] = key
+				break
+			}
+		}
+	}
+	if err := tree.loadValues(path, values); err != nil {
+		return err
+	}
+	for name, key := range keys {
+		if fruit := tree.figs[name]; len(fruit.provenance) > 0 {
+			fruit.provenance[len(fruit.provenance)-1].Key = key // the dotenv key like DB_HOST rather than db.host
+		}
+	}
+	return nil
+}
+
+// parseDotenv returns the keys and values of a dotenv file
+//
+// Lines may start with export. Unquoted values end at a # comment, single quoted values are literal, and double
+// quoted values understand \n, \r, \t, \", \\ and \$ escapes. Quoted values can span lines. ${VAR}, $VAR and
+// ${VAR:-default} expand in unquoted and double quoted values using earlier keys and then lookup.
+func parseDotenv(data []byte, lookup func(string) (string, bool)) (map[string]string, error) {
+	env := make(map[string]string)
+	expand := func(name string) (string, bool) {
+		if val, ok := env[name]; ok {
+			return val, true
+		}
+		return lookup(name)
+	}
+	src := strings.ReplaceAll(string(data), "\r\n", "\n")
+	line := 1
+	for len(src) > 0 {
+		var raw string
+		if i := strings.IndexByte(src, '\n'); i >= 0 {
+			raw, src = src[:i], src[i+1:]
+		} else {
+			raw, src = src, ""
+		}
+		start := line
+		line++
+		trimmed := strings.TrimSpace(raw)
+		if trimmed == "" || strings.HasPrefix(trimmed, "#") {
+			continue
+		}
+		if rest, ok := strings.CutPrefix(trimmed, "export"); ok && (rest == "" || rest[0] == ' ' || rest[0] == '\t') {
+			trimmed = strings.TrimSpace(rest)
+		}
+		key, value, ok := strings.Cut(trimmed, "=")
+		key = strings.TrimSpace(key)
+		if !ok || !validDotenvKey(key) {
+			return nil, fmt.Errorf("dotenv line %d: invalid assignment %q", start, raw)
+		}
+		value = strings.TrimLeft(value, " \t")
+		if value == "" {
+			env[key] = ""
+			continue
+		}
+		quote := value[0]
+		if quote != '"' && quote != '\'' {
+			if i := strings.Index(value, " #"); i >= 0 {
+				value = value[:i]
+			}
+			env[key] = expandDotenv(strings.TrimSpace(value), expand)
+			continue
+		}
+		body := value[1:]
+		end := closingQuote(body, quote)
+		for end < 0 && len(src) > 0 {
+			var next string
+			if i := strings.IndexByte(src, '\n'); i >= 0 {
+				next, src = src[:i], src[i+1:]
+			} else {
+				next, src = src, ""
+			}
+			line++
+			body += "\n" + next
+			end = closingQuote(body, quote)
+		}
+		if end < 0 {
+			return nil, fmt.Errorf("dotenv line %d: unterminated %c quote for %s", start, quote, key)
+		}
+		if tail := strings.TrimSpace(body[end+1:]); tail != "" && !strings.HasPrefix(tail, "#") {
+			return nil, fmt.Errorf("dotenv line %d: unexpected %q after quoted value of %s", start, tail, key)
+		}
+		body = body[:end]
+		if quote == '\'' {
+			env[key] = body
+			continue
+		}
+		env[key] = expandDotenv(body, expand)
+	}
+	return env, nil
+}
+
+// validDotenvKey reports whether key is made of letters, digits, underscores and dots without a leading digit
+func validDotenvKey(key string) bool {
+	if key == "" || (key[0] >= '0' && key[0] <= '9') {
+		return false
+	}
+	for _, r := range key {
+		if !(r == '_' || r == '.' || (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z') || (r >= '0' && r <= '9')) {
+			return false
+		}
+	}
+	return true
+}
+
+// closingQuote returns the index of the quote that ends body or -1 ; double quotes can be escaped with a backslash
+func closingQuote(body string, quote byte) int {
+	for i := 0; i < len(body); i++ {
+		switch {
+		case quote == '"' && body[i] == '\\':
+			i++
+		case body[i] == quote:
+			return i
+		}
+	}
+	return -1
+}
+
+// expandDotenv resolves escapes and ${VAR}, $VAR and ${VAR:-default} references in value
+func expandDotenv(value string, lookup func(string) (string, bool)) string {
+	var b strings.Builder
+	for i := 0; i < len(value); i++ {
+		c := value[i]
+		switch {
+		case c == '\\' && i+1 < len(value):
+			i++
+			switch value[i] {
+			case 'n':
+				b.WriteByte('\n')
+			case 'r':
+				b.WriteByte('\r')
+			case 't':
+				b.WriteByte('\t')
+			case '"', '\\', '$', '\'':
+				b.WriteByte(value[i])
+			default:
+				b.WriteByte('\\')
+				b.WriteByte(value[i])
+			}
+		case c == '$' && i+1 < len(value) && value[i+1] == '{':
+			end := strings.IndexByte(value[i:], '}')
+			if end < 0 {
+				b.WriteString(value[i:])
+				return b.String()
+			}
+			name, fallback, hasFallback := strings.Cut(value[i+2:i+end], ":-")
+			if val, ok := lookup(name); ok && (val != "" || !hasFallback) {
+				b.WriteString(val)
+			} else {
+				b.WriteString(fallback)
+			}
+			i += end
+		case c == '$' && i+1 < len(value) && (value[i+1] == '_' || isLetter(value[i+1])):
+			end := i + 1
+			for end < len(value) && (value[end] == '_' || isLetter(value[end]) || (value[end] >= '0' && value[end] <= '9')) {
+				end++
+			}
+			val, _ := lookup(value[i+1 : end])
+			b.WriteString(val)
+			i = end - 1
+		default:
+			b.WriteByte(c)
+		}
+	}
+	return b.String()
+}
+
+func isLetter(c byte) bool {
+	return (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
+}
+
+// dotenvBytes requires the figTree.mu to be locked and renders properties as a dotenv file sorted by key
+func (tree *figTree) dotenvBytes(properties map[string]interface{}) []byte {
+	lines := make([]string, 0, len(properties))
+	for name, value := range properties {
+		if value == nil {
+			continue
+		}
+		lines = append(lines, tree.envNamesOf(name)[0]+"="+quoteDotenv(dotenvValue(value)))
+	}
+	sort.Strings(lines)
+	return []byte(strings.Join(lines, "\n") + "\n")
+}
+
+// dotenvValue formats value the way it would be assigned from an environment variable
+func dotenvValue(value interface{}) string {
+	switch v := value.(type) {
+	case []string:
+		return strings.Join(v, ListSeparator)
+	case map[string]string:
+		keys := make([]string, 0, len(v))
+		for k := range v {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		pairs := make([]string, 0, len(v))
+		for _, k := range keys {
+			pairs = append(pairs, k+MapKeySeparator+v[k])
+		}
+		return strings.Join(pairs, MapSeparator)
+	case time.Duration:
+		return v.String()
+	default:
+		if s, err := toString(v); err == nil {
+			return s
+		}
+		return fmt.Sprintf("%v", v)
+	}
+}
+
+// quoteDotenv wraps value in double quotes and escapes it so parseDotenv returns it unchanged
+func quoteDotenv(value string) string {
+	r := strings.NewReplacer(`\`, `\\`, `"`, `\"`, `$`, `\$`, "\n", `\n`, "\r", `\r`, "\t", `\t`)
+	return `"` + r.Replace(value) + `"`
+}
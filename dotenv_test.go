@@ -0,0 +1,103 @@
+package figtree
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseDotenv(t *testing.T) {
+	lookup := func(name string) (string, bool) {
+		if name == "HOME" {
+			return "/home/app", true
+		}
+		return "", false
+	}
+	data := []byte(`# comment
+export NAME=app # trailing comment
+PLAIN = value with spaces
+EMPTY=
+SINGLE='literal ${HOME} \n'
+DOUBLE="tab\there \"quoted\" \$HOME"
+EXPANDED=${HOME}/data
+SHORT=$HOME/bin
+FALLBACK=${MISSING:-default}
+REUSED="${NAME}-${PLAIN}"
+MULTI="line one
+line two"
+MULTI_SINGLE='a
+b'
+WINDOWS=C:\path
+`)
+	env, err := parseDotenv(data, lookup)
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]string{
+		"NAME":         "app",
+		"PLAIN":        "value with spaces",
+		"EMPTY":        "",
+		"SINGLE":       `literal ${HOME} \n`,
+		"DOUBLE":       "tab\there \"quoted\" $HOME",
+		"EXPANDED":     "/home/app/data",
+		"SHORT":        "/home/app/bin",
+		"FALLBACK":     "default",
+		"REUSED":       "app-value with spaces",
+		"MULTI":        "line one\nline two",
+		"MULTI_SINGLE": "a\nb",
+		"WINDOWS":      `C:\path`,
+	}, env)
+
+	_, err = parseDotenv([]byte("KEY=\"unterminated\n"), lookup)
+	assert.ErrorContains(t, err, "unterminated")
+	_, err = parseDotenv([]byte("not an assignment\n"), lookup)
+	assert.ErrorContains(t, err, "line 1")
+	_, err = parseDotenv([]byte("KEY=\"value\" extra\n"), lookup)
+	assert.ErrorContains(t, err, "unexpected")
+}
+
+func TestTree_LoadFile_Dotenv(t *testing.T) {
+	os.Args = []string{os.Args[0]}
+	path := filepath.Join(t.TempDir(), ".env")
+	assert.NoError(t, os.WriteFile(path, []byte(`export NAME="my app"
+DB_HOST=db.local
+TIMEOUT=5s
+HOSTS=a,b
+LABELS=env=dev,team=core
+UNRELATED=ignored
+`), 0600))
+	figs := With(Options{Germinate: true, IgnoreEnvironment: true})
+	figs.NewString("name", "", "name")
+	figs.NewDuration("timeout", time.Second, "timeout")
+	figs.NewList("hosts", []string{}, "hosts")
+	figs.NewMap("labels", map[string]string{}, "labels")
+	figs.NewBranch("db").NewString("host", "", "database host")
+	assert.NoError(t, figs.LoadFile(path))
+	assert.Equal(t, "my app", *figs.String("name"))
+	assert.Equal(t, "db.local", *figs.String("db.host"))
+	assert.Equal(t, 5*time.Second, *figs.Duration("timeout"))
+	assert.Equal(t, []string{"a", "b"}, *figs.List("hosts"))
+	assert.Equal(t, map[string]string{"env": "dev", "team": "core"}, *figs.Map("labels"))
+	assert.Empty(t, figs.MutagenesisOfFig("unrelated"))
+
+	figs.StoreString("name", "quote \" dollar $HOME\nnewline")
+	saved := filepath.Join(t.TempDir(), "saved.env")
+	assert.NoError(t, figs.SaveTo(saved))
+	info, err := os.Stat(saved)
+	assert.NoError(t, err)
+	assert.Equal(t, os.FileMode(0600), info.Mode().Perm())
+
+	figs2 := With(Options{Germinate: true, IgnoreEnvironment: true})
+	figs2.NewString("name", "", "name")
+	figs2.NewDuration("timeout", time.Second, "timeout")
+	figs2.NewList("hosts", []string{}, "hosts")
+	figs2.NewMap("labels", map[string]string{}, "labels")
+	figs2.NewBranch("db").NewString("host", "", "database host")
+	assert.NoError(t, figs2.ReadFrom(saved))
+	assert.Equal(t, "quote \" dollar $HOME\nnewline", *figs2.String("name"))
+	assert.Equal(t, "db.local", *figs2.String("db.host"))
+	assert.Equal(t, 5*time.Second, *figs2.Duration("timeout"))
+	assert.Equal(t, []string{"a", "b"}, *figs2.List("hosts"))
+	assert.Equal(t, map[string]string{"env": "dev", "team": "core"}, *figs2.Map("labels"))
+}
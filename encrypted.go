@@ -0,0 +1,289 @@
+package figtree
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+)
+
+const (
+	envelopePrefix = "ENC["
+	envelopeSuffix = "]"
+)
+
+// Decrypter opens the ciphertext of an ENC[...] envelope in a config file
+type Decrypter interface {
+	Decrypt(ciphertext []byte) ([]byte, error)
+}
+
+// Encrypter seals a value into the ciphertext of an ENC[...] envelope for SaveToEncrypted
+type Encrypter interface {
+	Encrypt(plaintext []byte) ([]byte, error)
+}
+
+// AESKey is an AES-128, AES-192 or AES-256 key that seals values with AES-GCM behind a random nonce
+type AESKey []byte
+
+// NewAESKey decodes a base64 key of 16, 24 or 32 bytes like the contents of Options.KeyFile or EncryptionKeyEnv
+func NewAESKey(encoded string) (AESKey, error) {
+	key, err := base64.StdEncoding.DecodeString(strings.TrimSpace(encoded))
+	if err != nil {
+		return nil, fmt.Errorf("invalid key: %w", err)
+	}
+	if _, err := aes.NewCipher(key); err != nil {
+		return nil, fmt.Errorf("invalid key: %w", err)
+	}
+	return key, nil
+}
+
+// GenerateAESKey returns a random base64 AES-256 key for Options.KeyFile or EncryptionKeyEnv
+func GenerateAESKey() (string, error) {
+	key := make([]byte, 32)
+	if _, err := io.ReadFull(rand.Reader, key); err != nil {
+		return "", err
+	}
+	return base64.StdEncoding.EncodeToString(key), nil
+}
+
+// Encrypt returns the nonce followed by plaintext sealed with AES-GCM
+func (k AESKey) Encrypt(plaintext []byte) ([]byte, error) {
+	gcm, err := k.gcm()
+	if err != nil {
+		return nil, err
+	}
+	nonce := make([]byte, gcm.NonceSize(), gcm.NonceSize()+len(plaintext)+gcm.Overhead())
+	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+		return nil, err
+	}
+	return gcm.Seal(nonce, nonce, plaintext, nil), nil
+}
+
+// Decrypt opens the nonce and sealed plaintext returned by Encrypt
+func (k AESKey) Decrypt(ciphertext []byte) ([]byte, error) {
+	gcm, err := k.gcm()
+	if err != nil {
+		return nil, err
+	}
+	if len(ciphertext) < gcm.NonceSize() {
+		return nil, errors.New("ciphertext is too short")
+	}
+	nonce, sealed := ciphertext[:gcm.NonceSize()], ciphertext[gcm.NonceSize():]
+	return gcm.Open(nil, nonce, sealed, nil)
+}
+
+func (k AESKey) gcm() (cipher.AEAD, error) {
+	block, err := aes.NewCipher(k)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+// decrypter requires the figTree.mu to be locked and returns Options.Decrypter or else the AESKey read from
+// Options.KeyFile or else from the EncryptionKeyEnv environment variable
+func (tree *figTree) decrypter() (Decrypter, error) {
+	if tree.decrypt != nil {
+		return tree.decrypt, nil
+	}
+	if tree.keyFile != "" {
+		encoded, err := readSecretFile(tree.keyFile)
+		if err != nil {
+			return nil, fmt.Errorf("key file %s: %w", tree.keyFile, err)
+		}
+		return NewAESKey(encoded)
+	}
+	if encoded, ok := tree.getEnv(EncryptionKeyEnv); ok && encoded != "" {
+		return NewAESKey(encoded)
+	}
+	return nil, fmt.Errorf("no key for ENC[...] values ; set Options.Decrypter, Options.KeyFile or %s", EncryptionKeyEnv)
+}
+
+// open requires the figTree.mu to be locked and decrypts the ciphertext inside an ENC[...] envelope
+func (tree *figTree) open(ciphertext []byte) (string, error) {
+	d, err := tree.decrypter()
+	if err != nil {
+		return "", err
+	}
+	plaintext, err := d.Decrypt(ciphertext)
+	if err != nil {
+		return "", fmt.Errorf("cannot decrypt envelope: %w", err)
+	}
+	return string(plaintext), nil
+}
+
+// envelope returns the ciphertext of s when all of s, apart from surrounding spaces, is ENC[<base64>]
+func envelope(s string) ([]byte, bool) {
+	s = strings.TrimSpace(s)
+	if !strings.HasPrefix(s, envelopePrefix) || !strings.HasSuffix(s, envelopeSuffix) {
+		return nil, false
+	}
+	body := strings.TrimSuffix(strings.TrimPrefix(s, envelopePrefix), envelopeSuffix)
+	ciphertext, err := base64.StdEncoding.DecodeString(body)
+	if err != nil || len(ciphertext) == 0 {
+		return nil, false
+	}
+	return ciphertext, true
+}
+
+// unseal requires the figTree.mu to be locked and returns value with every ENC[...] envelope it holds decrypted and
+// whether it held one
+//
+// Only a string that is a whole envelope is sealed, so a value like "ENC[abc]" or "key=ENC[...]" is left as it is.
+// A List or a Map from YAML, JSON or TOML holds one envelope for each item or value, while SaveToEncrypted seals a
+// List or a Map in a dotenv file as a single envelope.
+func (tree *figTree) unseal(value interface{}) (interface{}, bool, error) {
+	switch v := value.(type) {
+	case string:
+		ciphertext, ok := envelope(v)
+		if !ok {
+			return v, false, nil
+		}
+		plaintext, err := tree.open(ciphertext)
+		if err != nil {
+			return nil, false, err
+		}
+		return plaintext, true, nil
+	case []interface{}:
+		list := make([]interface{}, len(v))
+		sealed := false
+		for i, item := range v {
+			opened, was, err := tree.unseal(item)
+			if err != nil {
+				return nil, false, err
+			}
+			list[i], sealed = opened, sealed || was
+		}
+		return list, sealed, nil
+	case []string:
+		list := make([]string, len(v))
+		sealed := false
+		for i, item := range v {
+			opened, was, err := tree.unseal(item)
+			if err != nil {
+				return nil, false, err
+			}
+			list[i], sealed = opened.(string), sealed || was
+		}
+		return list, sealed, nil
+	case map[string]interface{}:
+		m := make(map[string]interface{}, len(v))
+		sealed := false
+		for k, item := range v {
+			opened, was, err := tree.unseal(item)
+			if err != nil {
+				return nil, false, err
+			}
+			m[k], sealed = opened, sealed || was
+		}
+		return m, sealed, nil
+	case map[string]string:
+		m := make(map[string]string, len(v))
+		sealed := false
+		for k, item := range v {
+			opened, was, err := tree.unseal(item)
+			if err != nil {
+				return nil, false, err
+			}
+			m[k], sealed = opened.(string), sealed || was
+		}
+		return m, sealed, nil
+	default:
+		return value, false, nil
+	}
+}
+
+// seal returns value with its strings, or the items of a List or the values of a Map, in ENC[...] envelopes
+func seal(e Encrypter, value interface{}) (interface{}, error) {
+	switch v := value.(type) {
+	case []string:
+		list := make([]string, len(v))
+		for i, item := range v {
+			s, err := sealString(e, item)
+			if err != nil {
+				return nil, err
+			}
+			list[i] = s
+		}
+		return list, nil
+	case map[string]string:
+		m := make(map[string]string, len(v))
+		for k, item := range v {
+			s, err := sealString(e, item)
+			if err != nil {
+				return nil, err
+			}
+			m[k] = s
+		}
+		return m, nil
+	default:
+		s, err := toString(value)
+		if err != nil {
+			return nil, err
+		}
+		return sealString(e, s)
+	}
+}
+
+// sealString returns s as an ENC[...] envelope
+func sealString(e Encrypter, s string) (string, error) {
+	ciphertext, err := e.Encrypt([]byte(s))
+	if err != nil {
+		return "", err
+	}
+	return envelopePrefix + base64.StdEncoding.EncodeToString(ciphertext) + envelopeSuffix, nil
+}
+
+// SaveToEncrypted is SaveTo that writes fields and every fig with RuleSecret as ENC[...] envelopes
+//
+// Example:
+//
+//	figs := figtree.With(figtree.Options{KeyFile: "/etc/myapp/key"})
+//	figs.NewSecret("db.password", "database password")
+//	figs.NewString("db.host", "localhost", "database host")
+//	err := figs.Load()
+//	err = figs.SaveToEncrypted("config.yaml", "db.host") // db.host and db.password are both sealed
+//
+// The figs are sealed with the Encrypter of Options.Decrypter or with the AESKey of Options.KeyFile or
+// EncryptionKeyEnv. Loading the file opens the envelopes again and gives the figs they belong to RuleSecret.
+func (tree *figTree) SaveToEncrypted(path string, fields ...string) error {
+	tree.mu.Lock()
+	defer tree.mu.Unlock()
+	d, err := tree.decrypter()
+	if err != nil {
+		return err
+	}
+	e, ok := d.(Encrypter)
+	if !ok {
+		return fmt.Errorf("Options.Decrypter %T cannot Encrypt", d)
+	}
+	sealed := make(map[string]bool, len(fields))
+	for _, field := range fields {
+		name := tree.resolveName(field)
+		if _, exists := tree.figs[name]; !exists {
+			return fmt.Errorf("no fig named -%s", field)
+		}
+		sealed[name] = true
+	}
+	properties, err := tree.properties(func(name string, value interface{}) (interface{}, bool, error) {
+		if !sealed[name] && !tree.isSecret(name) {
+			return value, true, nil
+		}
+		if value == nil {
+			return value, true, nil
+		}
+		if isDotenv(path) {
+			value = dotenvValue(value) // one envelope for the whole List or Map
+		}
+		v, err := seal(e, value)
+		return v, true, err
+	})
+	if err != nil {
+		return err
+	}
+	return tree.writeProperties(path, properties)
+}
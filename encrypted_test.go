@@ -0,0 +1,137 @@
+package figtree
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTree_SaveToEncrypted(t *testing.T) {
+	os.Args = []string{os.Args[0]}
+	key, err := GenerateAESKey()
+	assert.NoError(t, err)
+	env := MapEnv{EncryptionKeyEnv: key}
+	grow := func() Plant {
+		figs := With(Options{Germinate: true, EnvSource: env})
+		figs.NewSecret("password", "database password")
+		figs.NewString("host", "localhost", "database host")
+		figs.NewList("tags", []string{}, "tags")
+		figs.NewInt("port", 5432, "database port")
+		return figs
+	}
+	for _, name := range []string{"config.yaml", "config.json", "config.toml", "config.ini", ".env"} {
+		figs := grow()
+		assert.NoError(t, figs.ParseArgs([]string{"-password", "hunter2", "-tags", "a,b", "-port", "6543"}), name)
+		path := filepath.Join(t.TempDir(), name)
+		assert.NoError(t, figs.SaveToEncrypted(path, "tags", "port"), name)
+		data, err := os.ReadFile(path)
+		assert.NoError(t, err)
+		assert.Contains(t, string(data), "ENC[", name)
+		assert.Contains(t, string(data), "localhost", name)
+		assert.NotContains(t, string(data), "hunter2", name)
+		assert.NotContains(t, string(data), "6543", name)
+
+		loaded := grow()
+		assert.NoError(t, loaded.LoadFile(path), name)
+		assert.Equal(t, "hunter2", *loaded.String("password"), name)
+		assert.Equal(t, "localhost", *loaded.String("host"), name)
+		assert.Equal(t, 6543, *loaded.Int("port"), name)
+		if name != "config.ini" { // SaveTo writes a List into INI as [a b]
+			assert.ElementsMatch(t, []string{"a", "b"}, *loaded.List("tags"), name)
+		}
+		assert.Equal(t, SecretMask, loaded.SourceOf("port").Value, "a decrypted value is a secret")
+		assert.Equal(t, "localhost", loaded.SourceOf("host").Value)
+	}
+}
+
+func TestTree_Unseal_Literal(t *testing.T) {
+	os.Args = []string{os.Args[0]}
+	dir := t.TempDir()
+	config := filepath.Join(dir, "config.yaml")
+	assert.NoError(t, os.WriteFile(config, []byte("pattern: ENC[abc]\nnote: see ENC[MnJldG51aA==] below\n"), 0644))
+	figs := With(Options{Germinate: true, IgnoreEnvironment: true})
+	figs.NewString("pattern", "", "pattern")
+	figs.NewString("note", "", "note")
+	assert.NoError(t, figs.LoadFile(config))
+	assert.Equal(t, "ENC[abc]", *figs.String("pattern"))
+	assert.Equal(t, "see ENC[MnJldG51aA==] below", *figs.String("note"))
+	assert.Equal(t, "ENC[abc]", figs.SourceOf("pattern").Value, "a literal is not a secret")
+
+	key, err := GenerateAESKey()
+	assert.NoError(t, err)
+	env := MapEnv{EncryptionKeyEnv: key}
+	grow := func() Plant {
+		figs := With(Options{Germinate: true, EnvSource: env})
+		figs.NewList("hosts", []string{}, "hosts")
+		figs.NewMap("labels", map[string]string{}, "labels")
+		return figs
+	}
+	figs = grow()
+	assert.NoError(t, figs.ParseArgs([]string{"-hosts", "a.local,b.local", "-labels", "env=prod,team=core"}))
+	dotenv := filepath.Join(dir, ".env")
+	assert.NoError(t, figs.SaveToEncrypted(dotenv, "hosts", "labels"))
+	data, err := os.ReadFile(dotenv)
+	assert.NoError(t, err)
+	assert.NotContains(t, string(data), "a.local")
+	assert.NotContains(t, string(data), ",")
+	loaded := grow()
+	assert.NoError(t, loaded.LoadFile(dotenv))
+	assert.ElementsMatch(t, []string{"a.local", "b.local"}, *loaded.List("hosts"))
+	assert.Equal(t, map[string]string{"env": "prod", "team": "core"}, *loaded.Map("labels"))
+}
+
+type reverse struct{}
+
+func (reverse) Decrypt(ciphertext []byte) ([]byte, error) {
+	plaintext := make([]byte, len(ciphertext))
+	for i, b := range ciphertext {
+		plaintext[len(ciphertext)-1-i] = b
+	}
+	return plaintext, nil
+}
+
+func TestTree_Decrypter(t *testing.T) {
+	os.Args = []string{os.Args[0]}
+	dir := t.TempDir()
+	key, err := GenerateAESKey()
+	assert.NoError(t, err)
+	aes, err := NewAESKey(key)
+	assert.NoError(t, err)
+	sealed, err := sealString(aes, "hunter2")
+	assert.NoError(t, err)
+	config := filepath.Join(dir, "config.yaml")
+	assert.NoError(t, os.WriteFile(config, []byte("password: "+sealed+"\n"), 0600))
+
+	keyFile := filepath.Join(dir, "key")
+	assert.NoError(t, os.WriteFile(keyFile, []byte(key+"\n"), 0600))
+	figs := With(Options{Germinate: true, IgnoreEnvironment: true, KeyFile: keyFile})
+	figs.NewString("password", "", "database password")
+	assert.NoError(t, figs.LoadFile(config))
+	assert.Equal(t, "hunter2", *figs.String("password"))
+
+	other, err := GenerateAESKey()
+	assert.NoError(t, err)
+	for _, opts := range []Options{
+		{Germinate: true, IgnoreEnvironment: true},
+		{Germinate: true, EnvSource: MapEnv{EncryptionKeyEnv: other}},
+		{Germinate: true, IgnoreEnvironment: true, KeyFile: filepath.Join(dir, "missing")},
+	} {
+		figs := With(opts)
+		figs.NewString("password", "", "database password")
+		err := figs.LoadFile(config)
+		var failure ErrLoadFailure
+		assert.True(t, errors.As(err, &failure))
+		assert.Equal(t, config, failure.What)
+		assert.Equal(t, "", *figs.String("password"))
+	}
+
+	assert.NoError(t, os.WriteFile(config, []byte("password: ENC[MnJldG51aA==]\n"), 0600))
+	figs = With(Options{Germinate: true, IgnoreEnvironment: true, Decrypter: reverse{}})
+	figs.NewString("password", "", "database password")
+	assert.NoError(t, figs.LoadFile(config))
+	assert.Equal(t, "hunter2", *figs.String("password"))
+	assert.Error(t, figs.SaveToEncrypted(filepath.Join(dir, "out.yaml")), "reverse cannot Encrypt")
+}
@@ -0,0 +1,145 @@
+package figtree
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+)
+
+// EnvSource supplies the environment variables that a figTree reads
+type EnvSource interface {
+	// LookupEnv returns the value of key and whether it is set like os.LookupEnv
+	LookupEnv(key string) (string, bool)
+	// Environ returns every variable as key=value like os.Environ
+	Environ() []string
+}
+
+type osEnv struct{}
+
+func (osEnv) LookupEnv(key string) (string, bool) {
+	return os.LookupEnv(key)
+}
+
+func (osEnv) Environ() []string {
+	return os.Environ()
+}
+
+// OSEnv reads the environment of the process and is the EnvSource used when Options.EnvSource is nil
+var OSEnv EnvSource = osEnv{}
+
+// MapEnv is an EnvSource backed by a map so tests and embedders never touch the process environment
+//
+// Example:
+//
+//	figs := figtree.With(figtree.Options{EnvSource: figtree.MapEnv{"PORT": "9090"}})
+type MapEnv map[string]string
+
+func (m MapEnv) LookupEnv(key string) (string, bool) {
+	v, ok := m[key]
+	return v, ok
+}
+
+func (m MapEnv) Environ() []string {
+	env := make([]string, 0, len(m))
+	for k, v := range m {
+		env = append(env, k+"="+v)
+	}
+	sort.Strings(env)
+	return env
+}
+
+// EnvKeyFunc turns the name of a fig into the name of its environment variable before Options.EnvPrefix is added
+type EnvKeyFunc func(name string) string
+
+// DefaultEnvKey upper-cases name and replaces dots and dashes with underscores so db.host becomes DB_HOST
+func DefaultEnvKey(name string) string {
+	return strings.NewReplacer(".", "_", "-", "_").Replace(strings.ToUpper(name))
+}
+
+// WithEnv replaces the environment variable name of name with envNames that are looked up in order
+//
+// Example:
+//
+//	figs := figtree.With(figtree.Options{EnvPrefix: "MYAPP"})
+//	figs.NewInt("port", 8080, "listen port")                 // MYAPP_PORT
+//	figs.WithEnv("port", "MYAPP_HTTP_PORT", "LEGACY_PORT")   // MYAPP_HTTP_PORT then LEGACY_PORT
+//
+// The names are used exactly as given, without Options.EnvPrefix or Options.EnvKey, so fallbacks can keep
+// reading the variables of an older release during a migration. The first name is shown in UsageString
+// and used by SaveTo for .env files.
+func (tree *figTree) WithEnv(name string, envNames ...string) Plant {
+	tree.mu.Lock()
+	defer tree.mu.Unlock()
+	name = tree.resolveName(name)
+	fruit, exists := tree.figs[name]
+	if !exists || fruit == nil {
+		tree.problems = append(tree.problems, fmt.Errorf("WithEnv: no fig named -%s", name))
+		return tree
+	}
+	if len(envNames) == 0 {
+		tree.problems = append(tree.problems, fmt.Errorf("WithEnv: -%s: no environment names", name))
+		return tree
+	}
+	var names []string
+	for _, env := range envNames {
+		env = strings.TrimSpace(env)
+		if env == "" || strings.ContainsAny(env, "= \t\n") {
+			tree.problems = append(tree.problems, fmt.Errorf("WithEnv: -%s: invalid environment name %q", name, env))
+			continue
+		}
+		names = append(names, env)
+	}
+	if len(names) != len(envNames) {
+		return tree
+	}
+	fruit.envNames = names
+	return tree
+}
+
+// getEnv looks key up in the EnvSource of the figTree
+func (tree *figTree) getEnv(key string) (string, bool) {
+	if tree.env == nil {
+		return OSEnv.LookupEnv(key)
+	}
+	return tree.env.LookupEnv(key)
+}
+
+// envNamesOf requires the figTree.mu to be locked and returns the environment variable names of name in lookup order
+//
+// Without WithEnv this is the Options.EnvPrefix joined to Options.EnvKey of name, like MYAPP_DB_HOST for db.host.
+// Without a prefix or custom EnvKey the upper-cased name like DB.HOST is tried last for older configurations.
+func (tree *figTree) envNamesOf(name string) []string {
+	if fruit, ok := tree.figs[name]; ok && fruit != nil && len(fruit.envNames) > 0 {
+		return fruit.envNames
+	}
+	key := DefaultEnvKey
+	if tree.envKey != nil {
+		key = tree.envKey
+	}
+	env := key(name)
+	if tree.envPrefix != "" {
+		return []string{strings.TrimSuffix(tree.envPrefix, "_") + "_" + env}
+	}
+	if upper := strings.ToUpper(name); tree.envKey == nil && upper != env {
+		return []string{env, upper}
+	}
+	return []string{env}
+}
+
+// envUsage requires the figTree.mu to be locked and returns the environment names of name for UsageString
+func (tree *figTree) envUsage(name string) string {
+	if tree.ignoreEnv || tree.HasRule(RuleNoEnv) {
+		return ""
+	}
+	fruit, ok := tree.figs[name]
+	if !ok || fruit == nil || fruit.HasRule(RuleNoEnv) {
+		return ""
+	}
+	names := tree.envNamesOf(name)
+	if len(fruit.envNames) == 0 {
+		// the upper-cased fallback of older configurations is not advertised
+		names = names[:1]
+	}
+	return "$" + strings.Join(names, "|$")
+}
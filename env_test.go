@@ -0,0 +1,131 @@
+package figtree
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDefaultEnvKey(t *testing.T) {
+	assert.Equal(t, "PORT", DefaultEnvKey("port"))
+	assert.Equal(t, "DB_HOST", DefaultEnvKey("db.host"))
+	assert.Equal(t, "LOG_LEVEL", DefaultEnvKey("log-level"))
+}
+
+func TestTree_EnvPrefix(t *testing.T) {
+	os.Args = []string{os.Args[0]}
+	t.Setenv("PORT", "1111")
+	t.Setenv("MYAPP_PORT", "2222")
+	t.Setenv("MYAPP_DB_HOST", "db.example.com")
+	figs := With(Options{Germinate: true, EnvPrefix: "MYAPP"})
+	figs.NewInt("port", 8080, "listen port")
+	figs.NewBranch("db").NewString("host", "localhost", "database host")
+	assert.NoError(t, figs.Parse())
+	assert.Equal(t, 2222, *figs.Int("port"))
+	assert.Equal(t, "db.example.com", *figs.String("db.host"))
+
+	usage := figs.UsageString()
+	assert.Contains(t, usage, "listen port ($MYAPP_PORT)")
+	assert.Contains(t, usage, "database host ($MYAPP_DB_HOST)")
+}
+
+func TestTree_EnvKey(t *testing.T) {
+	os.Args = []string{os.Args[0]}
+	t.Setenv("APP__DB__HOST", "db.example.com")
+	t.Setenv("DB.HOST", "ignored")
+	figs := With(Options{Germinate: true, EnvPrefix: "APP_", EnvKey: func(name string) string {
+		return "_" + strings.ToUpper(strings.ReplaceAll(name, ".", "__"))
+	}})
+	figs.NewString("db.host", "localhost", "database host")
+	assert.NoError(t, figs.Parse())
+	assert.Equal(t, "db.example.com", *figs.String("db.host"))
+}
+
+func TestTree_WithEnv(t *testing.T) {
+	os.Args = []string{os.Args[0]}
+	t.Setenv("LEGACY_PORT", "3333")
+	t.Setenv("PORT", "1111")
+	figs := With(Options{Germinate: true})
+	figs.NewInt("port", 8080, "listen port")
+	figs.WithEnv("port", "HTTP_PORT", "LEGACY_PORT")
+	figs.NewString("name", "app", "app name")
+	figs.WithRule("name", RuleNoEnv)
+	assert.NoError(t, figs.Parse())
+	assert.Equal(t, 3333, *figs.Int("port"))
+	usage := figs.UsageString()
+	assert.Contains(t, usage, "($HTTP_PORT|$LEGACY_PORT)")
+	assert.NotContains(t, usage, "$NAME")
+
+	t.Setenv("HTTP_PORT", "4444")
+	assert.NoError(t, figs.Reload())
+	assert.Equal(t, 4444, *figs.Int("port"))
+
+	figs.WithEnv("missing", "MISSING")
+	figs.WithEnv("port")
+	figs.WithEnv("port", "BAD NAME")
+	assert.Len(t, figs.Problems(), 3)
+}
+
+func TestTree_EnvPrefix_Dotenv(t *testing.T) {
+	os.Args = []string{os.Args[0]}
+	path := filepath.Join(t.TempDir(), ".env")
+	assert.NoError(t, os.WriteFile(path, []byte("PORT=1111\nMYAPP_PORT=2222\nOLD_NAME=legacy\n"), 0600))
+	figs := With(Options{Germinate: true, EnvPrefix: "MYAPP"})
+	figs.NewInt("port", 8080, "listen port")
+	figs.NewString("name", "app", "app name")
+	figs.WithEnv("name", "MYAPP_NAME", "OLD_NAME")
+	assert.NoError(t, figs.ReadFrom(path))
+	assert.Equal(t, 2222, *figs.Int("port"))
+	assert.Equal(t, "legacy", *figs.String("name"))
+
+	saved := filepath.Join(t.TempDir(), ".env")
+	assert.NoError(t, figs.SaveTo(saved))
+	data, err := os.ReadFile(saved)
+	assert.NoError(t, err)
+	assert.Equal(t, "MYAPP_NAME=\"legacy\"\nMYAPP_PORT=\"2222\"\n", string(data))
+}
+
+func TestMapEnv(t *testing.T) {
+	env := MapEnv{"B": "2", "A": "1"}
+	v, ok := env.LookupEnv("A")
+	assert.True(t, ok)
+	assert.Equal(t, "1", v)
+	_, ok = env.LookupEnv("C")
+	assert.False(t, ok)
+	assert.Equal(t, []string{"A=1", "B=2"}, env.Environ())
+}
+
+func TestTree_EnvSource(t *testing.T) {
+	os.Args = []string{os.Args[0]}
+	t.Setenv("PORT", "1111")
+	env := MapEnv{"PORT": "2222", "DB_HOST": "db.example.com", "DEBUG": "true", "HOSTS": "a,b"}
+	figs := With(Options{Germinate: true, Pollinate: true, EnvSource: env})
+	figs.NewInt("port", 8080, "listen port")
+	figs.NewBool("debug", false, "debug")
+	figs.NewList("hosts", []string{}, "hosts")
+	figs.NewBranch("db").NewString("host", "localhost", "database host")
+	assert.NoError(t, figs.Parse())
+	assert.Equal(t, 2222, *figs.Int("port"))
+	assert.Equal(t, "db.example.com", *figs.String("db.host"))
+	assert.True(t, *figs.Bool("debug"))
+	assert.Equal(t, []string{"a", "b"}, *figs.List("hosts"))
+
+	env["PORT"] = "3333"
+	env["DB_HOST"] = "other.example.com"
+	assert.Equal(t, 3333, *figs.Int("port"))
+	assert.Equal(t, "other.example.com", *figs.String("db.host"))
+}
+
+func TestTree_IgnoreEnvironment_KeepsProcessEnv(t *testing.T) {
+	os.Args = []string{os.Args[0]}
+	t.Setenv("PORT", "1111")
+	t.Setenv(EnvironmentKey, filepath.Join(t.TempDir(), "missing.yaml"))
+	figs := With(Options{Germinate: true, IgnoreEnvironment: true})
+	figs.NewInt("port", 8080, "listen port")
+	assert.NoError(t, figs.Load())
+	assert.Equal(t, 8080, *figs.Int("port"))
+	assert.Equal(t, "1111", os.Getenv("PORT"))
+}
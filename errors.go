@@ -13,7 +13,7 @@ func (tree *figTree) ErrorFor(name string) error {
 	if !exists || fruit == nil {
 		return fmt.Errorf("no tree named %s", name)
 	}
-	return fruit.Error
+	return tree.redact(fruit.Error)
 }
 
 func (fig *figFruit) Unwrap() error {
@@ -87,6 +87,20 @@ func (e ErrLoadFailure) Unwrap() error {
 	return e.Err
 }
 
+// ErrSignature is the cause of an ErrLoadFailure when a config file is not signed by one of Options.TrustedKeys
+type ErrSignature struct {
+	Signature string
+	Err       error
+}
+
+func (e ErrSignature) Error() string {
+	return fmt.Sprintf("invalid signature %s: %s", e.Signature, e.Err.Error())
+}
+
+func (e ErrSignature) Unwrap() error {
+	return e.Err
+}
+
 type ErrValidationFailure struct {
 	Err error
 }
@@ -0,0 +1,208 @@
+package figtree
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+)
+
+// FigType constrains the Go types a Fig can hold to the ones a Mutagenesis can store
+type FigType interface {
+	string | bool | int | int64 | float64 | time.Duration | []string | map[string]string
+}
+
+// Fig is a typed handle to a single fig on a figTree
+type Fig[T FigType] struct {
+	tree *figTree
+	name string
+	mut  Mutagenesis
+	err  error
+}
+
+// figObserver follows the value of a fig for Fig.Watch
+type figObserver struct {
+	notify func()
+}
+
+// NewFig registers name on tree with value and usage and returns a typed handle to it
+//
+// Example:
+//
+//	figs := figtree.Grow()
+//	port := figtree.NewFig(figs, "port", 8080, "listen port")
+//	err := figs.Parse()
+//	listen(port.Get()) // int, no pointer to dereference
+//	err = port.Set(9090)
+//
+// When name already exists with a matching Mutagenesis the handle adopts it, so NewFig[string] works with a
+// File or Directory, NewFig[int] with a Semaphore and NewFig[time.Duration] with a UnitDuration. A Mutagenesis
+// that does not match, or a tree that was not created by figtree, is reported by Err().
+func NewFig[T FigType](tree Plant, name string, value T, usage string) *Fig[T] {
+	fig := &Fig[T]{name: strings.ToLower(name), mut: MutagenesisOf(value)}
+	switch t := tree.(type) {
+	case *figTree:
+		fig.tree = t
+	case *figBranch:
+		fig.tree = t.tree
+		fig.name = t.key(fig.name)
+	default:
+		fig.err = fmt.Errorf("NewFig: -%s: unsupported Plant %T", fig.name, tree)
+		return fig
+	}
+	fig.tree.mu.RLock()
+	fig.name = fig.tree.resolveName(fig.name)
+	fruit, exists := fig.tree.figs[fig.name]
+	fig.tree.mu.RUnlock()
+	if !exists || fruit == nil {
+		fig.tree.Define(fig.name, value, usage, nil, nil, nil)
+		return fig
+	}
+	if !figAdopts(fig.mut, fruit.Mutagenesis) {
+		fig.err = fmt.Errorf("NewFig: -%s: %w", fig.name, ErrInvalidType{fruit.Mutagenesis, fig.mut})
+		return fig
+	}
+	fig.mut = fruit.Mutagenesis
+	return fig
+}
+
+// figAdopts reports whether a Fig holding the Go type of want can follow a fig of the Mutagenesis have
+func figAdopts(want, have Mutagenesis) bool {
+	switch {
+	case want == have:
+		return true
+	case want == tString:
+		return have == tFile || have == tDirectory
+	case want == tInt:
+		return have == tSemaphore
+	case want == tDuration:
+		return have == tUnitDuration
+	default:
+		return false
+	}
+}
+
+// Name returns the name of the fig the handle follows
+func (fig *Fig[T]) Name() string {
+	return fig.name
+}
+
+// Get returns the current value without pollinating from the environment or running read callbacks
+func (fig *Fig[T]) Get() T {
+	var zero T
+	if fig.tree == nil {
+		return zero
+	}
+	fig.tree.mu.RLock()
+	defer fig.tree.mu.RUnlock()
+	v, err := fig.value()
+	if err != nil {
+		return zero
+	}
+	return v
+}
+
+// Set stores value with Store and returns the error it recorded on the fig, if any
+func (fig *Fig[T]) Set(value T) error {
+	if fig.err != nil {
+		return fig.err
+	}
+	return fig.tree.store(fig.mut, fig.name, cloneRaw(value))
+}
+
+// Err returns the problems creating the handle joined with the errors recorded on the fig
+func (fig *Fig[T]) Err() error {
+	if fig.tree == nil {
+		return fig.err
+	}
+	fig.tree.mu.RLock()
+	defer fig.tree.mu.RUnlock()
+	fruit, ok := fig.tree.figs[fig.name]
+	if !ok || fruit == nil {
+		return errors.Join(fig.err, fmt.Errorf("no fig named -%s", fig.name))
+	}
+	return errors.Join(fig.err, fruit.Error)
+}
+
+// Watch returns a channel that receives the current value and then every new value until ctx is done
+//
+// Values are delivered whenever the fig settles after Store, Set, Parse, Load, Reload or a file Watch, even
+// without Options.Tracking. The channel keeps only the latest value, so a slow receiver skips intermediate
+// values instead of blocking the figTree. The channel is closed when ctx is done.
+func (fig *Fig[T]) Watch(ctx context.Context) <-chan T {
+	ch := make(chan T, 1)
+	if fig.tree == nil {
+		close(ch)
+		return ch
+	}
+	var last T
+	seen := false
+	observer := &figObserver{}
+	observer.notify = func() {
+		v, err := fig.value()
+		if err != nil || (seen && reflect.DeepEqual(v, last)) {
+			return
+		}
+		last, seen = v, true
+		select {
+		case ch <- v:
+		default:
+			select {
+			case <-ch:
+			default:
+			}
+			select {
+			case ch <- v:
+			default:
+			}
+		}
+	}
+	tree := fig.tree
+	tree.mu.Lock()
+	if tree.observers == nil {
+		tree.observers = make(map[string][]*figObserver)
+	}
+	tree.observers[fig.name] = append(tree.observers[fig.name], observer)
+	observer.notify()
+	tree.mu.Unlock()
+	context.AfterFunc(ctx, func() {
+		tree.mu.Lock()
+		defer tree.mu.Unlock()
+		observers := tree.observers[fig.name]
+		for i, o := range observers {
+			if o == observer {
+				tree.observers[fig.name] = append(observers[:i:i], observers[i+1:]...)
+				break
+			}
+		}
+		close(ch)
+	})
+	return ch
+}
+
+// value requires the figTree.mu to be locked and returns the value of the fig as T
+func (fig *Fig[T]) value() (T, error) {
+	var zero T
+	value, err := fig.tree.from(fig.name)
+	if err != nil {
+		return zero, err
+	}
+	raw, err := toMutagenesis(fig.mut, value.Value)
+	if err != nil {
+		return zero, err
+	}
+	v, ok := cloneRaw(raw).(T)
+	if !ok {
+		return zero, ErrInvalidType{fig.mut, MutagenesisOf(raw)}
+	}
+	return v, nil
+}
+
+// notifyObservers requires the figTree.mu to be locked and hands the value of name to every Fig.Watch following it
+func (tree *figTree) notifyObservers(name string) {
+	for _, observer := range tree.observers[name] {
+		observer.notify()
+	}
+}
@@ -0,0 +1,93 @@
+package figtree
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewFig(t *testing.T) {
+	os.Args = []string{os.Args[0], "-port", "9090"}
+	figs := With(Options{Germinate: true, IgnoreEnvironment: true, Tracking: true, Harvest: 10})
+	port := NewFig(figs, "port", 8080, "listen port")
+	hosts := NewFig(figs, "hosts", []string{"a"}, "hosts")
+	timeout := NewFig(figs, "timeout", 5*time.Second, "timeout")
+	labels := NewFig(figs, "labels", map[string]string{"env": "dev"}, "labels")
+	assert.NoError(t, figs.Parse())
+	assert.Equal(t, "port", port.Name())
+	assert.Equal(t, 9090, port.Get())
+	assert.Equal(t, []string{"a"}, hosts.Get())
+	assert.Equal(t, 5*time.Second, timeout.Get())
+	assert.Equal(t, map[string]string{"env": "dev"}, labels.Get())
+
+	assert.NoError(t, port.Set(7070))
+	assert.Equal(t, 7070, port.Get())
+	assert.Equal(t, 7070, *figs.Int("port"))
+	mutation := <-figs.Mutations()
+	assert.Equal(t, "port", mutation.Property)
+	assert.Equal(t, 7070, mutation.New)
+
+	got := hosts.Get()
+	got[0] = "changed"
+	assert.Equal(t, []string{"a"}, hosts.Get())
+	assert.NoError(t, hosts.Set([]string{"b", "c"}))
+	assert.Equal(t, []string{"b", "c"}, *figs.List("hosts"))
+	assert.NoError(t, port.Err())
+	os.Args = []string{os.Args[0]}
+}
+
+func TestNewFig_Adopt(t *testing.T) {
+	os.Args = []string{os.Args[0]}
+	figs := With(Options{Germinate: true, IgnoreEnvironment: true})
+	figs.NewString("name", "app", "app name")
+	figs.NewSemaphore("workers", 2, "workers")
+	figs.WithRule("name", RulePreventChange)
+	name := NewFig(figs, "name", "ignored", "ignored")
+	workers := NewFig(figs, "workers", 0, "ignored")
+	assert.NoError(t, figs.Parse())
+	assert.Equal(t, "app", name.Get())
+	assert.ErrorContains(t, name.Set("changed"), "RulePreventChange")
+	assert.Equal(t, 2, workers.Get())
+	assert.NoError(t, workers.Set(4))
+	assert.Equal(t, 4, figs.Semaphore("workers").Limit())
+
+	wrong := NewFig(figs, "name", 1, "wrong type")
+	assert.Error(t, wrong.Err())
+	assert.Error(t, wrong.Set(2))
+	assert.Equal(t, 0, wrong.Get())
+	assert.Equal(t, "app", name.Get())
+
+	db := figs.NewBranch("db")
+	host := NewFig(db, "host", "localhost", "database host")
+	assert.Equal(t, "db.host", host.Name())
+	assert.Equal(t, "localhost", *figs.String("db.host"))
+}
+
+func TestFig_Watch(t *testing.T) {
+	os.Args = []string{os.Args[0]}
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	assert.NoError(t, os.WriteFile(path, []byte("workers: 5\n"), 0644))
+	figs := With(Options{Germinate: true, IgnoreEnvironment: true})
+	workers := NewFig(figs, "workers", 1, "workers")
+	ctx, cancel := context.WithCancel(context.Background())
+	ch := workers.Watch(ctx)
+	assert.Equal(t, 1, <-ch)
+
+	assert.NoError(t, figs.LoadFile(path))
+	assert.Equal(t, 5, <-ch)
+	assert.NoError(t, workers.Set(6))
+	assert.NoError(t, workers.Set(7))
+	assert.Equal(t, 7, <-ch)
+	assert.NoError(t, figs.LoadFile(path))
+	assert.Equal(t, 5, <-ch)
+
+	cancel()
+	for range ch {
+	}
+	assert.NoError(t, workers.Set(8))
+	assert.Equal(t, 8, workers.Get())
+}
@@ -1,8 +1,11 @@
 package figtree
 
 import (
+	"crypto/ed25519"
 	"flag"
+	"fmt"
 	"os"
+	"strings"
 	"sync"
 	"sync/atomic"
 )
@@ -64,6 +67,10 @@ func With(opts Options) Plant {
 	angel := atomic.Bool{}
 	angel.Store(true)
 	chBuf := 1
+	interval := DefaultWatchInterval
+	if opts.WatchInterval > 0 {
+		interval = opts.WatchInterval
+	}
 	if opts.Tracking && opts.Harvest > 0 {
 		chBuf = opts.Harvest
 	}
@@ -73,6 +80,16 @@ func With(opts Options) Plant {
 		filterTests:    opts.Germinate,
 		pollinate:      opts.Pollinate,
 		tracking:       opts.Tracking,
+		watch:          opts.Watch,
+		envPrefix:      opts.EnvPrefix,
+		envKey:         opts.EnvKey,
+		args:           opts.Args,
+		posix:          opts.POSIX,
+		explain:        opts.Explain,
+		decrypt:        opts.Decrypter,
+		keyFile:        opts.KeyFile,
+		trustedKeys:    append([]ed25519.PublicKey(nil), opts.TrustedKeys...),
+		watchInterval:  interval,
 		harvest:        chBuf,
 		angel:          &angel,
 		problems:       make([]error, 0),
@@ -80,14 +97,39 @@ func With(opts Options) Plant {
 		figs:           make(map[string]*figFruit),
 		values:         &sync.Map{},
 		withered:       make(map[string]witheredFig),
+		fileStamps:     make(map[string]fileStamp),
+		branches:       make(map[string]*figBranch),
+		semaphores:     make(map[string]*figSemaphore),
 		mu:             sync.RWMutex{},
 		mutationsCh:    make(chan Mutation, chBuf),
 		flagSet:        flag.NewFlagSet(os.Args[0], flag.ContinueOnError),
 	}
 	fig.flagSet.Usage = fig.Usage
 	angel.Store(false)
-	if opts.IgnoreEnvironment {
-		os.Clearenv()
+	switch {
+	case opts.IgnoreEnvironment:
+		fig.env = MapEnv{}
+	case opts.EnvSource != nil:
+		fig.env = opts.EnvSource
+	default:
+		fig.env = OSEnv
+	}
+	fig.precedence = fig.orderOf(opts.Precedence)
+	for i, key := range opts.TrustedKeys {
+		if len(key) != ed25519.PublicKeySize {
+			fig.problems = append(fig.problems, fmt.Errorf("TrustedKeys: key %d is not an ed25519 public key", i))
+		}
+	}
+	if opts.Profiles != nil {
+		fig.profiles = true
+		fig.NewString(ConfigProfileFlag, strings.Join(opts.Profiles, ","), "comma separated profiles layered over the config files")
+	}
+	if opts.Explain {
+		fig.NewBool(ConfigExplainFlag, false, "print where every fig got its value")
+	}
+	if opts.AdoptCommandLine {
+		fig.adopted = make(map[string]*flag.Flag)
+		fig.adoptCommandLine()
 	}
 	return fig
 }
@@ -707,7 +707,14 @@ func TestCornerCases(t *testing.T) {
 		figs := With(Options{Germinate: true})
 		figs = figs.NewString("name", "Satan", "Your Name").WithAlias("name", "n")
 		assert.NoError(t, figs.Parse())
+		assert.Equal(t, "Andrei", *figs.String("name"), "DefaultPrecedence ranks flags above env")
+
+		figs = With(Options{Germinate: true, Precedence: []Source{SourceDefault, SourceFile, SourceFlag, SourceEnv}})
+		figs = figs.NewString("name", "Satan", "Your Name").WithAlias("name", "n")
+		assert.NoError(t, figs.Parse())
 		assert.Equal(t, "Yeshua", *figs.String("name"))
+		assert.NoError(t, os.Unsetenv("NAME"))
+		os.Args = []string{os.Args[0]}
 	})
 }
 
@@ -0,0 +1,54 @@
+package figtree
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// FileContents reads the contents of the file whose path is stored in name
+func (tree *figTree) FileContents(name string) ([]byte, error) {
+	path := tree.File(name)
+	if path == nil {
+		return nil, fmt.Errorf("no file named %s", name)
+	}
+	return os.ReadFile(*path)
+}
+
+// FileHandler opens the file whose path is stored in name for reading
+func (tree *figTree) FileHandler(name string) (*os.File, error) {
+	path := tree.File(name)
+	if path == nil {
+		return nil, fmt.Errorf("no file named %s", name)
+	}
+	return os.Open(*path)
+}
+
+// FileWriteContents writes contents into the file whose path is stored in name
+func (tree *figTree) FileWriteContents(name string, contents []byte) error {
+	path := tree.File(name)
+	if path == nil {
+		return fmt.Errorf("no file named %s", name)
+	}
+	return os.WriteFile(*path, contents, 0644)
+}
+
+// DirectoryFlushAll recursively removes everything inside the directory whose path is stored in name
+func (tree *figTree) DirectoryFlushAll(name string) error {
+	path := tree.Directory(name)
+	if path == nil {
+		return fmt.Errorf("no directory named %s", name)
+	}
+	entries, err := os.ReadDir(*path)
+	if err != nil {
+		return err
+	}
+	var errs []error
+	for _, entry := range entries {
+		if err := os.RemoveAll(filepath.Join(*path, entry.Name())); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
@@ -0,0 +1,120 @@
+package figtree
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTree_NewFile(t *testing.T) {
+	os.Args = []string{os.Args[0]}
+	dir := t.TempDir()
+	path := filepath.Join(dir, "motd.txt")
+	assert.NoError(t, os.WriteFile(path, []byte("hello"), 0644))
+
+	figs := With(Options{Germinate: true, IgnoreEnvironment: true, Tracking: true, Harvest: 3})
+	figs.NewFile("motd", path, "message of the day")
+	figs.WithValidators("motd", AssureFileExists, AssureFileSizeGreaterThan(0), AssureFileModeIs(0644))
+	assert.NoError(t, figs.Parse())
+	assert.Equal(t, path, *figs.File("motd"))
+	assert.True(t, figs.FigFlesh("motd").IsFile())
+	assert.True(t, figs.FigFlesh("motd").Is(tFile))
+	assert.Equal(t, path, figs.FigFlesh("motd").ToFile())
+
+	contents, err := figs.FileContents("motd")
+	assert.NoError(t, err)
+	assert.Equal(t, "hello", string(contents))
+
+	assert.NoError(t, figs.FileWriteContents("motd", []byte("goodbye")))
+	handler, err := figs.FileHandler("motd")
+	assert.NoError(t, err)
+	written, err := io.ReadAll(handler)
+	assert.NoError(t, err)
+	assert.NoError(t, handler.Close())
+	assert.Equal(t, "goodbye", string(written))
+
+	other := filepath.Join(dir, "other.txt")
+	figs.StoreFile("motd", other)
+	mutation := <-figs.Mutations()
+	assert.Equal(t, "StoreFile", mutation.Way)
+	assert.Equal(t, path, mutation.Old)
+	assert.Equal(t, other, mutation.New)
+	assert.Equal(t, other, *figs.File("motd"))
+	assert.NoError(t, figs.ErrorFor("motd"))
+
+	_, err = figs.FileContents("missing")
+	assert.Error(t, err)
+}
+
+func TestTree_NewFile_Validators(t *testing.T) {
+	dir := t.TempDir()
+	missing := filepath.Join(dir, "missing.txt")
+
+	assert.Error(t, AssureFileExists(missing))
+	assert.Error(t, AssureFileExists(42))
+	assert.NoError(t, AssureFileTouchIfNotExists()(missing))
+	assert.NoError(t, AssureFileExists(missing))
+	assert.NoError(t, AssureFileCanBeModified(missing))
+	assert.Error(t, AssureFileSizeGreaterThan(0)(missing))
+	assert.NoError(t, AssureFileSizeLessThan(1)(missing))
+	assert.NoError(t, AssureFileOwnerIs(strconv.Itoa(os.Getuid()))(missing))
+
+	os.Args = []string{os.Args[0]}
+	figs := With(Options{Germinate: true, IgnoreEnvironment: true})
+	figs.NewFile("cert", filepath.Join(dir, "nope.pem"), "certificate")
+	figs.WithValidator("cert", AssureFileExists)
+	assert.Error(t, figs.Parse())
+}
+
+func TestTree_NewDirectory(t *testing.T) {
+	os.Args = []string{os.Args[0]}
+	dir := filepath.Join(t.TempDir(), "cache")
+
+	figs := With(Options{Germinate: true, IgnoreEnvironment: true})
+	figs.NewDirectory("cache", dir, "cache directory")
+	figs.WithValidators("cache", AssureDirCreateIfNotExists, AssureDirExists, AssureDirIsReadable, AssureDirIsWritable, AssureDirChmod(0755))
+	assert.NoError(t, figs.Parse())
+	assert.Equal(t, dir, *figs.Directory("cache"))
+	assert.True(t, figs.FigFlesh("cache").IsDirectory())
+	assert.False(t, figs.FigFlesh("cache").IsFile())
+
+	assert.NoError(t, os.MkdirAll(filepath.Join(dir, "nested", "deeper"), 0755))
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "entry.txt"), []byte("x"), 0644))
+	assert.NoError(t, figs.DirectoryFlushAll("cache"))
+	entries, err := os.ReadDir(dir)
+	assert.NoError(t, err)
+	assert.Empty(t, entries)
+
+	assert.NoError(t, AssureDirMorePermissiveThan(0700)(dir))
+	assert.Error(t, AssureDirLessPermissiveThan(0700)(dir))
+	assert.Error(t, AssureDirExists(filepath.Join(dir, "missing")))
+}
+
+func TestTree_File_LoadAndSave(t *testing.T) {
+	os.Args = []string{os.Args[0]}
+	dir := t.TempDir()
+	config := filepath.Join(dir, "config.yaml")
+	assert.NoError(t, os.WriteFile(config, []byte("data: /var/lib/app\nkey: /etc/app/key.pem\n"), 0644))
+
+	figs := With(Options{Germinate: true, IgnoreEnvironment: true})
+	figs.NewDirectory("data", "/tmp", "data directory")
+	figs.NewFile("key", "", "key file")
+	assert.NoError(t, figs.ReadFrom(config))
+	assert.Equal(t, "/var/lib/app", *figs.Directory("data"))
+	assert.Equal(t, "/etc/app/key.pem", *figs.File("key"))
+	assert.Contains(t, figs.UsageString(), "[File]")
+	assert.Contains(t, figs.UsageString(), "[Directory]")
+
+	saved := filepath.Join(dir, "saved.json")
+	assert.NoError(t, figs.SaveTo(saved))
+	figs2 := With(Options{Germinate: true, IgnoreEnvironment: true})
+	figs2.NewDirectory("data", "", "data directory")
+	figs2.NewFile("key", "", "key file")
+	assert.NoError(t, figs2.ReadFrom(saved))
+	assert.Equal(t, "/var/lib/app", *figs2.Directory("data"))
+	assert.Equal(t, "/etc/app/key.pem", *figs2.File("key"))
+}
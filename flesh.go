@@ -2,6 +2,7 @@ package figtree
 
 import (
 	"fmt"
+	"os"
 	"strconv"
 	"strings"
 	"sync/atomic"
@@ -149,6 +150,16 @@ func (flesh *figFlesh) ToUnitDuration() time.Duration {
 	return flesh.ToDuration()
 }
 
+// ToFile returns the Flesh as a file path
+func (flesh *figFlesh) ToFile() string {
+	return flesh.ToString()
+}
+
+// ToDirectory returns the Flesh as a directory path
+func (flesh *figFlesh) ToDirectory() string {
+	return flesh.ToString()
+}
+
 func (flesh *figFlesh) ToList() []string {
 	switch f := flesh.Flesh.(type) {
 	case *figFlesh:
@@ -231,6 +242,12 @@ func (flesh *figFlesh) Is(mutagenesis Mutagenesis) bool {
 		return flesh.IsDuration()
 	case tUnitDuration:
 		return flesh.IsUnitDuration()
+	case tFile:
+		return flesh.IsFile()
+	case tDirectory:
+		return flesh.IsDirectory()
+	case tSemaphore:
+		return flesh.IsInt()
 	default:
 		return false
 	}
@@ -353,6 +370,24 @@ func (flesh *figFlesh) IsList() bool {
 	}
 }
 
+// IsFile checks that the Flesh is a path to an existing regular file
+func (flesh *figFlesh) IsFile() bool {
+	if !flesh.IsString() {
+		return false
+	}
+	info, err := os.Stat(flesh.ToString())
+	return err == nil && info.Mode().IsRegular()
+}
+
+// IsDirectory checks that the Flesh is a path to an existing directory
+func (flesh *figFlesh) IsDirectory() bool {
+	if !flesh.IsString() {
+		return false
+	}
+	info, err := os.Stat(flesh.ToString())
+	return err == nil && info.IsDir()
+}
+
 func (flesh *figFlesh) getStringBool(in string) bool {
 	for _, e := range strings.Split(in, MapSeparator) {
 		if strings.Index(e, MapKeySeparator) == -1 {
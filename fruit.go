@@ -21,7 +21,7 @@ func (v *Value) IsBoolFlag() bool {
 
 func (v *Value) Set(in string) error {
 	switch v.Mutagensis {
-	case tString:
+	case tString, tFile, tDirectory:
 		v.Value = in
 	case tBool:
 		if len(in) == 0 {
@@ -33,7 +33,7 @@ func (v *Value) Set(in string) error {
 			return v.Err
 		}
 		v.Value = val
-	case tInt:
+	case tInt, tSemaphore:
 		if len(in) == 0 {
 			in = "0"
 		}
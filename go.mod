@@ -3,6 +3,7 @@ module github.com/andreimerlescu/figtree/v2
 go 1.23.4
 
 require (
+	github.com/BurntSushi/toml v1.6.0
 	github.com/andreimerlescu/checkfs v1.0.4
 	github.com/go-ini/ini v1.67.0
 	github.com/stretchr/testify v1.10.0
@@ -16,36 +16,44 @@ import (
 
 // CONFIGURABLE INTERNAL FUNCTIONS
 
-// loadFile will parse the filename for .yaml or .ini or .json and run the related loadJSON, loadYAML, or loadINI on it
+// loadFile will parse the filename for .yaml or .ini or .json or .toml or .env and run the related loadJSON, loadYAML, loadINI, loadTOML or loadDotenv on it
 func (tree *figTree) loadFile(filename string) error {
 	data, err := os.ReadFile(filename)
 	if err != nil {
 		return err
 	}
+	if data, err = tree.verify(filename, data); err != nil {
+		return err
+	}
+	if isDotenv(filename) {
+		return tree.loadDotenv(filename, data)
+	}
 	ext := strings.ToLower(filepath.Ext(filename))
 	switch ext {
 	case ".json":
-		return tree.loadJSON(data)
+		return tree.loadJSON(filename, data)
 	case ".yaml", ".yml":
-		return tree.loadYAML(data)
+		return tree.loadYAML(filename, data)
 	case ".ini":
-		return tree.loadINI(data)
+		return tree.loadINI(filename, data)
+	case ".toml":
+		return tree.loadTOML(filename, data)
 	default:
 		return errors.New("unsupported file extension")
 	}
 }
 
 // loadJSON parses the DefaultJSONFile or the value of the EnvironmentKey or ConfigFilePath into json.Unmarshal
-func (tree *figTree) loadJSON(data []byte) error {
+func (tree *figTree) loadJSON(path string, data []byte) error {
 	var jsonData map[string]interface{}
 	if err := json.Unmarshal(data, &jsonData); err != nil {
 		return err
 	}
-	return tree.setValuesFromMap(jsonData)
+	return tree.setValuesFrom(path, jsonData)
 }
 
 // loadINI parses the DefaultINIFile or the value of the EnvironmentKey or ConfigFilePath into ini.Load()
-func (tree *figTree) loadINI(data []byte) error {
+func (tree *figTree) loadINI(path string, data []byte) error {
 	cfg, err := ini.Load(data)
 	if err != nil {
 		return err
@@ -76,18 +84,30 @@ func (tree *figTree) loadINI(data []byte) error {
 			}
 		}
 	}
-	return tree.setValuesFromMap(iniData)
+	return tree.setValuesFrom(path, iniData)
 }
 
 // setValuesFromMap uses the data map to store the configurable figs
 func (tree *figTree) setValuesFromMap(data map[string]interface{}) error {
+	return tree.setValuesFrom("", data)
+}
+
+// setValuesFrom is setValuesFromMap that records each key of the file at path as the Provenance of its fig
+func (tree *figTree) setValuesFrom(path string, data map[string]interface{}) error {
 	tree.mu.Lock()
 	defer tree.mu.Unlock()
-	for key, value := range data {
+	for key, value := range tree.flattenBranches(data) {
 		name := tree.resolveName(key)
 		_, exists := tree.figs[name]
-		if exists {
-			if err := tree.mutateFig(name, value); err != nil {
+		if exists && !tree.isArg(name) && !tree.outranked(name, SourceFile) {
+			value, sealed, err := tree.unseal(value)
+			if err != nil {
+				return fmt.Errorf("error decrypting key %s: %w", key, err)
+			}
+			if sealed {
+				tree.markSecret(name)
+			}
+			if err := tree.mutateFig(name, tree.layer(name, value), Provenance{Source: SourceFile, Origin: path, Key: key}); err != nil {
 				return fmt.Errorf("error setting key %s: %w", key, err)
 			}
 		}
@@ -181,33 +201,85 @@ func (tree *figTree) setValue(flagVal interface{}, value interface{}) error {
 	return nil
 }
 
-// readEnv checks the os.LookupEnv on each figFruit in the figTree
-func (tree *figTree) readEnv() {
+// readEnv checks the EnvSource on each figFruit in the figTree and returns the errors of the secret files it could not read
+func (tree *figTree) readEnv() error {
+	tree.mu.Lock()
+	defer tree.mu.Unlock()
 	if tree.HasRule(RuleNoEnv) {
-		return
+		return nil
 	}
+	var errs []error
 	for name := range tree.figs {
-		tree.checkAndSetFromEnv(name)
+		errs = append(errs, tree.checkAndSetFromEnv(name))
 	}
-	return
+	return errors.Join(errs...)
 }
 
-// checkAndSetFromEnv uses os.LookupEnv and assigns it to the figs name value
-func (tree *figTree) checkAndSetFromEnv(name string) {
+// checkAndSetFromEnv requires the figTree.mu to be locked and uses the EnvSource and assigns it to the figs name value
+//
+// With RuleSecretFiles, a fig without its environment variable is read from the file named by the same variable
+// with a _FILE suffix, like DB_PASSWORD_FILE=/run/secrets/db.
+func (tree *figTree) checkAndSetFromEnv(name string) error {
 	if tree.HasRule(RuleNoEnv) {
-		return
+		return nil
+	}
+	if fruit, ok := tree.figs[name]; ok && fruit != nil && fruit.HasRule(RuleNoEnv) {
+		return nil
+	}
+	if tree.isArg(name) {
+		return nil // the argument given on the command line is never replaced
+	}
+	if tree.outranked(name, SourceEnv) {
+		return nil
+	}
+	if tree.ignoreEnv {
+		return nil
+	}
+	if env, val, exists := tree.lookupEnvName(name); exists {
+		_ = tree.mutateFig(name, val, Provenance{Source: SourceEnv, Origin: env})
+		return nil
+	}
+	if !tree.secretFiles(name) {
+		return nil
+	}
+	for _, env := range tree.envNamesOf(name) {
+		path, exists := tree.getEnv(env + SecretFileSuffix)
+		if !exists {
+			continue
+		}
+		val, err := readSecretFile(path)
+		if err != nil {
+			return ErrLoadFailure{env + SecretFileSuffix, err}
+		}
+		_ = tree.mutateFig(name, val, Provenance{Source: SourceEnv, Origin: env + SecretFileSuffix})
+		return nil
 	}
-	if !tree.ignoreEnv {
-		name = strings.ToUpper(name)
-		if val, exists := os.LookupEnv(name); exists {
-			_ = tree.mutateFig(name, val)
+	return nil
+}
+
+// lookupEnv requires the figTree.mu to be locked and uses the EnvSource on each environment name of a fig in order
+func (tree *figTree) lookupEnv(name string) (string, bool) {
+	_, val, exists := tree.lookupEnvName(name)
+	return val, exists
+}
+
+// lookupEnvName requires the figTree.mu to be locked and is lookupEnv that also returns the environment name that was set
+//
+// A positional argument has no environment variable, so PATH never replaces NewArg("path", ...).
+func (tree *figTree) lookupEnvName(name string) (string, string, bool) {
+	if tree.isArg(name) {
+		return "", "", false
+	}
+	for _, env := range tree.envNamesOf(name) {
+		if val, exists := tree.getEnv(env); exists {
+			return env, val, true
 		}
 	}
-	return
+	return "", "", false
 }
 
-// mutateFig replaces the value interface{} and sends a Mutation into Mutations
-func (tree *figTree) mutateFig(name string, value interface{}) error {
+// mutateFig replaces the value interface{}, records from as its Provenance and sends a Mutation into Mutations
+func (tree *figTree) mutateFig(name string, value interface{}, from Provenance) error {
 	name = tree.resolveName(name)
 	def, ok := tree.figs[name]
 	if !ok || def == nil {
@@ -232,31 +304,24 @@ func (tree *figTree) mutateFig(name string, value interface{}) error {
 	if !strings.EqualFold(t1, t2) {
 		return fmt.Errorf("type mismatch for key %s", name)
 	}
+	tree.trace(name, from)
 	// if tree.tracking && old != dead && dead != value
 	oldNotDead := !reflect.DeepEqual(old, dead)
 	notDeadWithValue := !reflect.DeepEqual(dead, value)
 	if tree.tracking && oldNotDead && notDeadWithValue {
-		tree.mutationsCh <- Mutation{
+		tree.mutationsCh <- tree.redactMutation(Mutation{
 			Property:    name,
 			Mutagenesis: fmt.Sprintf("%T", value),
 			Way:         "mutateFig",
 			Old:         old,
 			New:         value,
 			When:        time.Now(),
-		}
+			Provenance:  from,
+		})
 	}
 	return nil
 }
 
-// activateFlagSet sets flag.CommandLine to figTree.flagSet
-func (tree *figTree) activateFlagSet() Plant {
-	if tree.HasRule(RuleNoFlags) {
-		return tree
-	}
-	flag.CommandLine = tree.flagSet
-	return tree
-}
-
 // assignFlagSet assigns a new *flag.FlagSet to figTree.flagSet
 func (tree *figTree) assignFlagSet(newSet *flag.FlagSet) Plant {
 	if tree.HasRule(RuleNoFlags) {
@@ -266,6 +331,17 @@ func (tree *figTree) assignFlagSet(newSet *flag.FlagSet) Plant {
 	return tree
 }
 
+// argv returns Options.Args when it was set or else os.Args[1:]
+func (tree *figTree) argv() []string {
+	if tree.args != nil {
+		return append([]string(nil), tree.args...)
+	}
+	if len(os.Args) < 2 {
+		return []string{}
+	}
+	return os.Args[1:]
+}
+
 // filterTestFlags removes test-specific flags (e.g., -test.v) from the args slice
 func filterTestFlags(args []string) []string {
 	var filtered []string
@@ -0,0 +1,276 @@
+package figtree
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+)
+
+// interpolation resolves the ${name}, ${env:NAME} and ${file:/path} references of a figTree once
+type interpolation struct {
+	tree     *figTree
+	resolved map[string]interface{}
+	stack    []string
+}
+
+// interpolate resolves the references in every String, File, Directory, List and Map fig and sends a Mutation for
+// each derived value that changed
+//
+// Example:
+//
+//	figs.NewString("host", "localhost", "server host")
+//	figs.NewString("url", "https://${host}:8443", "public url")
+//	figs.NewFile("key", "${env:HOME}/.ssh/id_ed25519", "ssh key")
+//	figs.NewString("token", "${file:/run/secrets/token}", "api token")
+//	err := figs.Parse() // -host example.com makes url https://example.com:8443
+//
+// With RuleSecretFiles, a String given as file:///run/secrets/token is replaced by the contents of that file.
+// The value given by any source is kept as the template of the fig, so the derived value is resolved again when
+// Store, Reload or a hot reload changes a fig it refers to. A value without references replaces the template and
+// $${ writes a literal ${. A ${name} that is not a fig and a ${ without its closing } are kept as they are, while
+// ${file:/path} must pass the same checks as a secret file.
+func (tree *figTree) interpolate() error {
+	tree.mu.Lock()
+	mutations, err := tree.interpolateFigs()
+	tree.mu.Unlock()
+	tree.sendMutations(mutations)
+	return err
+}
+
+// interpolateFigs requires the figTree.mu to be locked and is interpolate that returns the Mutations to send
+func (tree *figTree) interpolateFigs() ([]Mutation, error) {
+	names := make([]string, 0, len(tree.figs))
+	for name, fruit := range tree.figs {
+		if fruit == nil || !interpolates(fruit.Mutagenesis) {
+			continue
+		}
+		value, err := tree.from(name)
+		if err != nil || value == nil {
+			continue
+		}
+		raw, err := toMutagenesis(fruit.Mutagenesis, value.Value)
+		if err != nil {
+			continue
+		}
+		switch {
+		case fruit.template != nil && reflect.DeepEqual(raw, fruit.derived):
+		case hasReferences(raw), isSecretFile(raw) && tree.secretFiles(name):
+			fruit.template = cloneRaw(raw)
+		default:
+			fruit.template, fruit.derived = nil, nil
+			continue
+		}
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	in := &interpolation{tree: tree, resolved: make(map[string]interface{}, len(names))}
+	mutations := make([]Mutation, 0)
+	for _, name := range names {
+		resolved, err := in.fig(name)
+		if err != nil {
+			return mutations, ErrInvalidValue{name, err}
+		}
+		fruit := tree.figs[name]
+		value, err := tree.from(name)
+		if err != nil {
+			return mutations, ErrInvalidValue{name, err}
+		}
+		old, _ := toMutagenesis(fruit.Mutagenesis, value.Value)
+		fruit.derived = cloneRaw(resolved)
+		if reflect.DeepEqual(old, resolved) {
+			continue
+		}
+		if err := value.Assign(cloneRaw(resolved)); err != nil {
+			return mutations, ErrInvalidValue{name, err}
+		}
+		tree.values.Store(name, value)
+		template, _ := toString(fruit.template)
+		from := Provenance{Source: SourceInterpolate, Origin: template}
+		tree.trace(name, from)
+		tree.ripenFig(name)
+		if tree.tracking && !tree.angel.Load() {
+			mutations = append(mutations, tree.redactMutation(Mutation{
+				Property:    name,
+				Mutagenesis: strings.ToLower(string(fruit.Mutagenesis)),
+				Way:         "Interpolate",
+				Old:         old,
+				New:         resolved,
+				When:        time.Now(),
+				Provenance:  from,
+			}))
+		}
+	}
+	return mutations, nil
+}
+
+// sendMutations sends each Mutation into Mutations without holding the figTree.mu
+func (tree *figTree) sendMutations(mutations []Mutation) {
+	for _, mutation := range mutations {
+		tree.mutationsCh <- mutation
+	}
+}
+
+// fig returns the resolved value of the fig name, resolving the figs it refers to first
+func (in *interpolation) fig(name string) (interface{}, error) {
+	if resolved, ok := in.resolved[name]; ok {
+		return resolved, nil
+	}
+	for i, visiting := range in.stack {
+		if visiting == name {
+			return nil, fmt.Errorf("interpolation cycle %s", strings.Join(append(in.stack[i:], name), " -> "))
+		}
+	}
+	fruit, ok := in.tree.figs[name]
+	if !ok || fruit == nil {
+		return nil, fmt.Errorf("unknown fig ${%s}", name)
+	}
+	var template interface{}
+	if fruit.template != nil && interpolates(fruit.Mutagenesis) {
+		template = fruit.template
+	} else {
+		value, err := in.tree.from(name)
+		if err != nil {
+			return nil, err
+		}
+		if template, err = toMutagenesis(fruit.Mutagenesis, value.Value); err != nil {
+			return nil, err
+		}
+		if !interpolates(fruit.Mutagenesis) {
+			in.resolved[name] = template
+			return template, nil
+		}
+	}
+	in.stack = append(in.stack, name)
+	defer func() { in.stack = in.stack[:len(in.stack)-1] }()
+	var resolved interface{}
+	switch t := template.(type) {
+	case string:
+		if isSecretFile(t) && in.tree.secretFiles(name) {
+			s, err := readSecretFile(strings.TrimPrefix(t, secretFileScheme))
+			if err != nil {
+				return nil, err
+			}
+			resolved = s
+			break
+		}
+		s, err := in.expand(t)
+		if err != nil {
+			return nil, err
+		}
+		resolved = s
+	case []string:
+		list := make([]string, len(t))
+		for i, item := range t {
+			s, err := in.expand(item)
+			if err != nil {
+				return nil, err
+			}
+			list[i] = s
+		}
+		resolved = list
+	case map[string]string:
+		m := make(map[string]string, len(t))
+		for k, item := range t {
+			s, err := in.expand(item)
+			if err != nil {
+				return nil, err
+			}
+			m[k] = s
+		}
+		resolved = m
+	default:
+		resolved = t
+	}
+	in.resolved[name] = resolved
+	return resolved, nil
+}
+
+// expand replaces every reference in s with its value
+func (in *interpolation) expand(s string) (string, error) {
+	var sb strings.Builder
+	for i := 0; i < len(s); {
+		switch {
+		case strings.HasPrefix(s[i:], "$${"):
+			sb.WriteString("${")
+			i += 3
+		case strings.HasPrefix(s[i:], "${"):
+			end := strings.Index(s[i:], "}")
+			if end < 0 {
+				sb.WriteString(s[i:])
+				return sb.String(), nil
+			}
+			value, err := in.reference(s[i+2 : i+end])
+			if err != nil {
+				return "", err
+			}
+			sb.WriteString(value)
+			i += end + 1
+		default:
+			sb.WriteByte(s[i])
+			i++
+		}
+	}
+	return sb.String(), nil
+}
+
+// reference returns the value of a fig name, env:NAME with an optional :-default or the contents of file:/path
+//
+// A name that is not a fig is returned as the literal ${name} so values that merely contain ${ are left alone.
+func (in *interpolation) reference(ref string) (string, error) {
+	switch {
+	case strings.HasPrefix(ref, "env:"):
+		key, fallback, _ := strings.Cut(strings.TrimPrefix(ref, "env:"), ":-")
+		if value, ok := in.tree.getEnv(key); ok {
+			return value, nil
+		}
+		return fallback, nil
+	case strings.HasPrefix(ref, "file:"):
+		return readSecretFile(strings.TrimPrefix(ref, "file:"))
+	default:
+		name := in.tree.resolveName(strings.TrimSpace(ref))
+		if fruit, ok := in.tree.figs[name]; !ok || fruit == nil {
+			return "${" + ref + "}", nil
+		}
+		resolved, err := in.fig(name)
+		if err != nil {
+			return "", err
+		}
+		if s, ok := resolved.(string); ok {
+			return s, nil
+		}
+		return toString(resolved)
+	}
+}
+
+// interpolates reports whether the values of mut can hold references
+func interpolates(mut Mutagenesis) bool {
+	switch mut {
+	case tString, tFile, tDirectory, tList, tMap:
+		return true
+	default:
+		return false
+	}
+}
+
+// hasReferences reports whether a String, List or Map value holds ${
+func hasReferences(raw interface{}) bool {
+	switch v := raw.(type) {
+	case string:
+		return strings.Contains(v, "${")
+	case []string:
+		for _, item := range v {
+			if strings.Contains(item, "${") {
+				return true
+			}
+		}
+	case map[string]string:
+		for _, item := range v {
+			if strings.Contains(item, "${") {
+				return true
+			}
+		}
+	}
+	return false
+}
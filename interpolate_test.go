@@ -0,0 +1,146 @@
+package figtree
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTree_Interpolate(t *testing.T) {
+	os.Args = []string{os.Args[0]}
+	dir := t.TempDir()
+	secret := filepath.Join(dir, "token")
+	assert.NoError(t, os.WriteFile(secret, []byte("s3cr3t\n"), 0600))
+	config := filepath.Join(dir, "config.yaml")
+	assert.NoError(t, os.WriteFile(config, []byte("url: https://${host}:${port}/${db.name}\n"), 0644))
+
+	figs := With(Options{Germinate: true, EnvSource: MapEnv{"DATA": "/srv"}, ConfigFile: config})
+	figs.NewString("host", "localhost", "host")
+	figs.NewInt("port", 8080, "port")
+	figs.NewBranch("db").NewString("name", "app", "database")
+	figs.NewString("url", "", "url")
+	figs.NewDirectory("data", "/var/lib", "data directory")
+	figs.NewString("cache", "${env:CACHE:-/tmp}/${data}", "cache directory")
+	figs.NewString("token", "${file:"+secret+"}", "token")
+	figs.NewString("literal", "$${host}", "literal")
+	figs.NewList("mirrors", []string{"${host}", "backup"}, "mirrors")
+	figs.NewMap("links", map[string]string{"home": "https://${host}"}, "links")
+	assert.NoError(t, figs.LoadWithArgs([]string{"-host", "example.com"}))
+
+	assert.Equal(t, "https://example.com:8080/app", *figs.String("url"))
+	assert.Equal(t, "/tmp//srv", *figs.String("cache"))
+	assert.Equal(t, "s3cr3t", *figs.String("token"))
+	assert.Equal(t, "${host}", *figs.String("literal"))
+	assert.ElementsMatch(t, []string{"example.com", "backup"}, *figs.List("mirrors"))
+	assert.Equal(t, map[string]string{"home": "https://example.com"}, *figs.Map("links"))
+	url := figs.SourceOf("url")
+	assert.Equal(t, SourceInterpolate, url.Source)
+	assert.Equal(t, "https://${host}:${port}/${db.name}", url.Origin)
+}
+
+func TestTree_Interpolate_Errors(t *testing.T) {
+	os.Args = []string{os.Args[0]}
+	figs := With(Options{Germinate: true, IgnoreEnvironment: true})
+	figs.NewString("a", "${b}", "a")
+	figs.NewString("b", "x-${c}", "b")
+	figs.NewString("c", "${a}", "c")
+	err := figs.ParseArgs([]string{})
+	assert.ErrorContains(t, err, "interpolation cycle a -> b -> c -> a")
+
+	figs = With(Options{Germinate: true, IgnoreEnvironment: true})
+	figs.NewString("a", "${file:/does/not/exist}", "a")
+	assert.Error(t, figs.ParseArgs([]string{}))
+
+	open := filepath.Join(t.TempDir(), "token")
+	assert.NoError(t, os.WriteFile(open, []byte("s3cr3t\n"), 0666))
+	assert.NoError(t, os.Chmod(open, 0666))
+	figs = With(Options{Germinate: true, IgnoreEnvironment: true})
+	figs.NewString("a", "${file:"+open+"}", "a")
+	assert.ErrorContains(t, figs.ParseArgs([]string{}), "secret file")
+}
+
+func TestTree_Interpolate_Literal(t *testing.T) {
+	os.Args = []string{os.Args[0]}
+	figs := With(Options{Germinate: true, IgnoreEnvironment: true})
+	figs.NewString("greeting", "Hello ${user}!", "greeting")
+	assert.NoError(t, figs.ParseArgs([]string{}))
+	assert.Equal(t, "Hello ${user}!", *figs.String("greeting"))
+
+	figs = With(Options{Germinate: true, IgnoreEnvironment: true})
+	figs.NewString("user", "ada", "user")
+	figs.NewString("greeting", "Hello ${user}!", "greeting")
+	figs.NewString("template", "Hello ${name}, meet ${user}!", "template")
+	figs.NewString("shell", "echo ${HOME", "shell")
+	figs.NewList("paths", []string{"${prefix}/bin"}, "paths")
+	assert.NoError(t, figs.ParseArgs([]string{}))
+	assert.Equal(t, "Hello ada!", *figs.String("greeting"))
+	assert.Equal(t, "Hello ${name}, meet ada!", *figs.String("template"))
+	assert.Equal(t, "echo ${HOME", *figs.String("shell"))
+	assert.Equal(t, []string{"${prefix}/bin"}, *figs.List("paths"))
+}
+
+func TestTree_Interpolate_Store(t *testing.T) {
+	os.Args = []string{os.Args[0]}
+	env := MapEnv{}
+	figs := With(Options{Germinate: true, Tracking: true, Harvest: 10, EnvSource: env})
+	figs.NewString("host", "localhost", "host")
+	figs.NewString("url", "http://${host}/", "url")
+	assert.NoError(t, figs.ParseArgs([]string{}))
+	assert.Equal(t, "http://localhost/", *figs.String("url"))
+	<-figs.Mutations() // url derived by ParseArgs
+
+	figs.StoreString("host", "example.com")
+	assert.Equal(t, "http://example.com/", *figs.String("url"))
+	stored := <-figs.Mutations()
+	assert.Equal(t, "host", stored.Property)
+	derived := <-figs.Mutations()
+	assert.Equal(t, "url", derived.Property)
+	assert.Equal(t, "Interpolate", derived.Way)
+	assert.Equal(t, "http://localhost/", derived.Old)
+	assert.Equal(t, "http://example.com/", derived.New)
+
+	env["HOST"] = "reloaded.local"
+	assert.NoError(t, figs.Reload())
+	assert.Equal(t, "http://reloaded.local/", *figs.String("url"))
+
+	figs.StoreString("url", "http://fixed/")
+	figs.StoreString("host", "other")
+	assert.Equal(t, "http://fixed/", *figs.String("url"))
+
+	figs.StoreString("url", "https://${host}")
+	assert.Equal(t, "https://other", *figs.String("url"))
+}
+
+func TestTree_Interpolate_Watch(t *testing.T) {
+	os.Args = []string{os.Args[0]}
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	assert.NoError(t, os.WriteFile(path, []byte("host: one.local\nurl: http://${host}/\n"), 0644))
+	figs := With(Options{
+		ConfigFile:        path,
+		Watch:             true,
+		WatchInterval:     10 * time.Millisecond,
+		Tracking:          true,
+		Harvest:           10,
+		Germinate:         true,
+		IgnoreEnvironment: true,
+	})
+	figs.NewString("host", "", "host")
+	figs.NewString("url", "", "url")
+	assert.NoError(t, figs.Load())
+	defer figs.StopWatching()
+	assert.Equal(t, "http://one.local/", *figs.String("url"))
+
+	assert.NoError(t, os.WriteFile(path, []byte("host: two.local\nurl: http://${host}/\n"), 0644))
+	assert.Eventually(t, func() bool {
+		return *figs.String("url") == "http://two.local/"
+	}, 3*time.Second, 10*time.Millisecond)
+	assert.Equal(t, SourceInterpolate, figs.SourceOf("url").Source)
+
+	assert.NoError(t, os.WriteFile(path, []byte("host: two.local\nurl: https://${host}/\n"), 0644))
+	assert.Eventually(t, func() bool {
+		return *figs.String("url") == "https://two.local/"
+	}, 3*time.Second, 10*time.Millisecond)
+}
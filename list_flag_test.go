@@ -39,3 +39,17 @@ func TestListFlag_Set(t *testing.T) {
 		os.Args = []string{os.Args[0]}
 	})
 }
+
+func TestTree_LoadFlagSet_ListOrder(t *testing.T) {
+	os.Args = []string{os.Args[0]}
+	for i := 0; i < 20; i++ {
+		figs := With(Options{Germinate: true, IgnoreEnvironment: true})
+		figs.NewList("tags", []string{"web", "api"}, "tags")
+		assert.NoError(t, figs.ParseArgs([]string{"-tags", "zeta,alpha,mid,alpha"}))
+		value, err := figs.(*figTree).from("tags")
+		assert.NoError(t, err)
+		tags, err := toStringSlice(value.Value)
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"zeta", "alpha", "mid"}, tags, "flag values keep their order without repeats")
+	}
+}
@@ -7,7 +7,9 @@ import (
 	"os"
 	"path/filepath"
 	"strings"
+	"time"
 
+	"github.com/BurntSushi/toml"
 	check "github.com/andreimerlescu/checkfs"
 	"github.com/andreimerlescu/checkfs/file"
 	"gopkg.in/yaml.v3"
@@ -15,11 +17,18 @@ import (
 
 // Reload will readEnv on each flag in the configurable package
 func (tree *figTree) Reload() error {
-	tree.readEnv()
+	defer tree.ripen()
+	if err := tree.readEnv(); err != nil {
+		return err
+	}
+	if err := tree.interpolate(); err != nil {
+		return err
+	}
 	return tree.validateAll()
 }
 
 func (tree *figTree) preLoadOrParse() error {
+	tree.adoptCommandLine()
 	tree.mu.RLock()
 	defer tree.mu.RUnlock()
 	for name, fig := range tree.figs {
@@ -37,18 +46,26 @@ func (tree *figTree) preLoadOrParse() error {
 	return tree.checkFigErrors()
 }
 
-// Load uses the EnvironmentKey and the DefaultJSONFile, DefaultYAMLFile, and DefaultINIFile to run ParseFile if it exists
+// Load uses the EnvironmentKey and the DefaultJSONFile, DefaultYAMLFile, DefaultINIFile, and DefaultTOMLFile to run ParseFile if it exists
 func (tree *figTree) Load() (err error) {
+	return tree.LoadWithArgs(tree.argv())
+}
+
+// LoadWithArgs is Load with args in place of Options.Args or os.Args[1:]
+func (tree *figTree) LoadWithArgs(args []string) (err error) {
+	defer tree.ripen()
 	preloadErr := tree.preLoadOrParse()
 	if preloadErr != nil {
 		return preloadErr
 	}
 	if !tree.HasRule(RuleNoFlags) {
-		tree.activateFlagSet()
-		args := os.Args[1:]
 		if tree.filterTests {
 			args = filterTestFlags(args)
 		}
+		args, err = tree.posixArgs(args)
+		if err != nil {
+			return ErrLoadFailure{"flags", err}
+		}
 		err = tree.flagSet.Parse(args)
 		if err != nil {
 			err2 := tree.checkFigErrors()
@@ -57,14 +74,49 @@ func (tree *figTree) Load() (err error) {
 			}
 			return ErrLoadFailure{"flags", err}
 		}
-		err = tree.loadFlagSet()
+		tree.captureFlags()
+		err = tree.selectCommand()
 		if err != nil {
-			return err
+			return ErrLoadFailure{"flags", err}
+		}
+		err = tree.bindArgs()
+		if err != nil {
+			return ErrLoadFailure{"flags", err}
 		}
 	}
+	err = tree.resolve(map[Source]func() error{
+		SourceFile: tree.loadConfigFiles,
+		SourceEnv:  tree.readEnvStep,
+		SourceFlag: tree.loadFlagSetStep,
+	})
+	if err != nil {
+		return err
+	}
+	err = tree.checkFigErrors()
+	if err != nil {
+		return fmt.Errorf("checkFigErrors() threw err: %w", err)
+	}
+	err = tree.interpolate()
+	if err != nil {
+		return err
+	}
+	tree.explainIfAsked()
+	err = tree.validateAll()
+	if err != nil {
+		return err
+	}
+	err = tree.startWatching()
+	if err != nil {
+		return err
+	}
+	return tree.dispatch()
+}
+
+// loadConfigFiles layers the file named by the EnvironmentKey, the ConfigFile and the default config files that exist
+func (tree *figTree) loadConfigFiles() error {
 	first := ""
 	if !tree.HasRule(RuleNoEnv) {
-		first = os.Getenv(EnvironmentKey)
+		first, _ = tree.getEnv(EnvironmentKey)
 	}
 	files := []string{
 		first,
@@ -72,38 +124,53 @@ func (tree *figTree) Load() (err error) {
 		ConfigFilePath,
 		filepath.Join(".", DefaultJSONFile),
 		filepath.Join(".", DefaultINIFile),
+		filepath.Join(".", DefaultTOMLFile),
 	}
+	found := make([]string, 0, len(files))
 	for i := 0; i < len(files); i++ {
 		f := files[i]
 		if f == "" {
 			continue
 		}
 		if err := check.File(f, file.Options{Exists: true}); err == nil {
-			if err := tree.loadFile(f); err != nil {
-				return ErrLoadFailure{f, err}
-			}
+			found = append(found, f)
 		}
 	}
-	tree.readEnv()
-	err = tree.checkFigErrors()
-	if err != nil {
-		return fmt.Errorf("checkFigErrors() threw err: %w", err)
-	}
-	return tree.validateAll()
+	return tree.loadLayers(found)
 }
 
 // LoadFile accepts a path and uses it to populate the figTree
 func (tree *figTree) LoadFile(path string) (err error) {
+	return tree.LoadFiles(path)
+}
+
+// LoadFiles is LoadFile for several paths that are merged in order so a later file overrides the keys of an earlier one
+//
+// Example:
+//
+//	figs := figtree.With(figtree.Options{Profiles: []string{}})
+//	figs.NewList("hosts", []string{}, "upstream hosts")
+//	err := figs.LoadFiles("base.yaml", "prod.yaml") // app -profile=eu also layers base.eu.yaml and prod.eu.yaml
+//
+// A Map or a List given by several files is merged when PolicyMapAppend or PolicyListAppend is enabled and is
+// replaced by the later file otherwise. Each path is followed by its overlay for every active profile that exists.
+// The paths that exist are loaded even when another is missing, then the first missing path is returned as an
+// ErrLoadFailure.
+func (tree *figTree) LoadFiles(paths ...string) (err error) {
+	defer tree.ripen()
 	preloadErr := tree.preLoadOrParse()
 	if preloadErr != nil {
 		return preloadErr
 	}
 	if !tree.HasRule(RuleNoFlags) {
-		tree.activateFlagSet()
-		args := os.Args[1:]
+		args := tree.argv()
 		if tree.filterTests {
 			args = filterTestFlags(args)
 		}
+		args, err = tree.posixArgs(args)
+		if err != nil {
+			return err
+		}
 		err = tree.flagSet.Parse(args)
 		if err != nil {
 			err2 := tree.checkFigErrors()
@@ -112,28 +179,51 @@ func (tree *figTree) LoadFile(path string) (err error) {
 			}
 			return err
 		}
+		tree.captureFlags()
+		err = tree.selectCommand()
+		if err != nil {
+			return err
+		}
+		err = tree.bindArgs()
+		if err != nil {
+			return err
+		}
 	}
 	var loadErr error
-	if loadErr = check.File(path, file.Options{Exists: true}); loadErr == nil {
-		if err2 := tree.loadFile(path); err2 != nil {
-			return ErrLoadFailure{path, err2}
-		}
-		tree.readEnv()
-		err3 := tree.loadFlagSet()
-		if err3 != nil {
-			return err3
+	found := make([]string, 0, len(paths))
+	for _, path := range paths {
+		if err := check.File(path, file.Options{Exists: true}); err != nil {
+			if loadErr == nil {
+				loadErr = ErrLoadFailure{path, err}
+			}
+			continue
 		}
+		found = append(found, path)
+	}
+	err = tree.resolve(map[Source]func() error{
+		SourceFile: func() error { return tree.loadLayers(found) },
+		SourceEnv:  tree.readEnvStep,
+		SourceFlag: tree.loadFlagSetStep,
+	})
+	if err != nil {
+		return err
+	}
+	err = tree.interpolate()
+	if err != nil {
+		return err
+	}
+	tree.explainIfAsked()
+	if loadErr == nil {
 		err4 := tree.validateAll()
 		if err4 != nil {
 			return ErrValidationFailure{err4}
 		}
-		return nil
-	}
-	err3 := tree.loadFlagSet()
-	if err3 != nil {
-		return err3
+		err = tree.startWatching()
+		if err != nil {
+			return err
+		}
+		return tree.dispatch()
 	}
-	tree.readEnv()
 	err4 := tree.checkFigErrors()
 	if err4 != nil {
 		return fmt.Errorf("failed to checkFigErrors: %w", err4)
@@ -142,7 +232,7 @@ func (tree *figTree) LoadFile(path string) (err error) {
 	if err5 != nil {
 		return ErrValidationFailure{err5}
 	}
-	return ErrLoadFailure{path, loadErr}
+	return loadErr
 }
 
 func (tree *figTree) loadFlagSet() (e error) {
@@ -158,6 +248,10 @@ func (tree *figTree) loadFlagSet() (e error) {
 	}()
 	tree.mu.Lock()
 	defer tree.mu.Unlock()
+	set := make(map[string]bool)
+	tree.flagSet.Visit(func(f *flag.Flag) {
+		set[f.Name] = true
+	})
 	tree.flagSet.VisitAll(func(f *flag.Flag) {
 		flagName := f.Name
 		for alias, name := range tree.aliases {
@@ -175,7 +269,7 @@ func (tree *figTree) loadFlagSet() (e error) {
 			merged := value.Flesh().ToMap()
 			withered := tree.withered[flagName]
 			witheredValue := withered.Value.Flesh().ToMap()
-			flagged, err := toStringMap(f.Value)
+			flagged, err := toStringMap(tree.flagValue(f))
 			if err != nil {
 				e = ErrLoadFailure{flagName, err}
 				return
@@ -203,21 +297,20 @@ func (tree *figTree) loadFlagSet() (e error) {
 				e = ErrLoadFailure{flagName, err}
 				return
 			}
-			flagged, err := toStringSlice(f.Value)
+			flagged, err := toStringSlice(tree.flagValue(f))
 			if err != nil {
 				e = ErrLoadFailure{flagName, err}
 				return
 			}
 			unique := make(map[string]bool)
-			for _, v := range merged {
-				unique[v] = true
-			}
-			for _, v := range flagged {
-				unique[v] = true
-			}
 			var newValue []string
-			for k, _ := range unique {
-				newValue = append(newValue, k)
+			for _, list := range [][]string{merged, flagged} {
+				for _, v := range list {
+					if !unique[v] {
+						unique[v] = true
+						newValue = append(newValue, v)
+					}
+				}
 			}
 			err = value.Assign(newValue)
 			if err != nil {
@@ -226,30 +319,137 @@ func (tree *figTree) loadFlagSet() (e error) {
 			}
 		default:
 			v := f.Value.String()
+			if flagged, ok := tree.flagValue(f).(string); ok {
+				v = flagged
+			}
 			err := value.Set(v)
 			if err != nil {
-				e = ErrLoadFailure{flagName, fmt.Errorf("failed to value.Set(%s): %w", f.Value.String(), err)}
+				e = ErrLoadFailure{flagName, fmt.Errorf("failed to value.Set(%v): %w", tree.masked(flagName, v), tree.maskedError(flagName, err))}
 				return
 			}
 		}
 		tree.values.Store(flagName, value)
+		if set[f.Name] {
+			tree.trace(flagName, Provenance{Source: SourceFlag, Origin: "-" + f.Name})
+		}
 	})
 	return nil
 }
 
 // loadYAML parses the DefaultYAMLFile or the value of the EnvironmentKey or ConfigFilePath into yaml.Unmarshal
-func (tree *figTree) loadYAML(data []byte) error {
+func (tree *figTree) loadYAML(path string, data []byte) error {
 	var yamlData map[string]interface{}
 	if err := yaml.Unmarshal(data, &yamlData); err != nil {
 		return err
 	}
 	tree.mu.Lock()
 	defer tree.mu.Unlock()
-	tree.activateFlagSet()
-	for n, d := range yamlData {
+	return tree.loadValues(path, tree.flattenBranches(yamlData))
+}
+
+// loadTOML parses a .toml file into toml.Decode where tables become branches or dotted names, arrays become a List and inline tables become a Map
+func (tree *figTree) loadTOML(path string, data []byte) error {
+	var tomlData map[string]interface{}
+	meta, err := toml.Decode(string(data), &tomlData)
+	if err != nil {
+		return err
+	}
+	tree.mu.Lock()
+	defer tree.mu.Unlock()
+	return tree.loadValues(path, tree.flattenTOML(tomlData, meta, tomlHeaders(data)))
+}
+
+// flattenTOML requires the figTree.mu to be locked and turns the tables of a TOML document into dotted fig names
+//
+// A table named like a fig keeps its keys and values as a Map. A table that is a branch, or that holds dotted
+// figs like server.port, is flattened into them, as is any other [table] header. An unknown inline table becomes
+// a Map and unknown arrays become a List.
+func (tree *figTree) flattenTOML(data map[string]interface{}, meta toml.MetaData, headers map[string]bool) map[string]interface{} {
+	flat := make(map[string]interface{}, len(data))
+	var flatten func(prefix string, keys []string, data map[string]interface{})
+	flatten = func(prefix string, keys []string, data map[string]interface{}) {
+		for key, value := range data {
+			name := prefix + strings.ToLower(key)
+			path := append(keys[:len(keys):len(keys)], key)
+			switch v := value.(type) {
+			case map[string]interface{}:
+				if _, exists := tree.figs[name]; !exists && (tree.isBranchPath(name) || tree.hasFigsUnder(name) || headers[name] || meta.Type(path...) == "") {
+					flatten(name+".", path, v)
+					continue
+				}
+				if m, err := toStringMap(v); err == nil {
+					flat[name] = m
+					continue
+				}
+				flat[name] = v
+			case []interface{}:
+				if l, err := toStringSlice(v); err == nil {
+					flat[name] = l
+					continue
+				}
+				flat[name] = v
+			case time.Time:
+				flat[name] = v.Format(time.RFC3339Nano)
+			default:
+				flat[name] = v
+			}
+		}
+	}
+	flatten("", nil, data)
+	return flat
+}
+
+// hasFigsUnder requires the figTree.mu to be locked and reports whether a fig is named like prefix.name
+func (tree *figTree) hasFigsUnder(prefix string) bool {
+	for name := range tree.figs {
+		if strings.HasPrefix(name, prefix+".") {
+			return true
+		}
+	}
+	return false
+}
+
+// tomlHeaders returns the lowercase dotted names of every [table] and [[array]] header in a TOML document
+func tomlHeaders(data []byte) map[string]bool {
+	headers := make(map[string]bool)
+	for _, line := range strings.Split(string(data), "\n") {
+		line = strings.TrimSpace(line)
+		if !strings.HasPrefix(line, "[") {
+			continue
+		}
+		end := strings.Index(line, "]")
+		if end < 0 {
+			continue
+		}
+		segments := strings.Split(strings.Trim(line[:end], "[ \t"), ".")
+		for i, segment := range segments {
+			segments[i] = strings.ToLower(strings.Trim(strings.TrimSpace(segment), `"'`))
+		}
+		headers[strings.Join(segments, ".")] = true
+	}
+	return headers
+}
+
+// loadValues requires the figTree.mu to be locked and assigns the flattened values of a config file to their figs
+//
+// Keys without a fig are registered as new figs whose Mutagenesis comes from their value. Each key is recorded with
+// path as the Provenance of its fig.
+func (tree *figTree) loadValues(path string, data map[string]interface{}) error {
+	for n, d := range data {
+		d, sealed, err := tree.unseal(d)
+		if err != nil {
+			return fmt.Errorf("error decrypting key %s: %w", n, err)
+		}
 		var fruit *figFruit
 		var exists bool
 		if fruit, exists = tree.figs[n]; exists && fruit != nil {
+			if tree.isArg(fruit.name) || tree.outranked(fruit.name, SourceFile) {
+				continue
+			}
+			if sealed {
+				tree.markSecret(fruit.name)
+			}
+			d = tree.layer(fruit.name, d)
 			value := tree.useValue(tree.from(fruit.name))
 			var ds string
 			var err error
@@ -282,8 +482,10 @@ func (tree *figTree) loadYAML(data []byte) error {
 				}
 			}
 			tree.values.Store(fruit.name, value)
+			tree.trace(fruit.name, Provenance{Source: SourceFile, Origin: path, Key: n})
 			continue
 		}
+		tree.layer(n, d)
 		mut := tree.MutagenesisOf(d)
 		vf, er := tree.from(n)
 		if er == nil && vf != nil && strings.EqualFold(string(vf.Mutagensis), string(tree.MutagenesisOf(d))) {
@@ -318,6 +520,10 @@ func (tree *figTree) loadYAML(data []byte) error {
 		}
 		tree.figs[n] = fruit
 		tree.withered[n] = withered
+		if sealed {
+			tree.markSecret(n)
+		}
+		tree.trace(n, Provenance{Source: SourceFile, Origin: path, Key: n})
 	}
 
 	return nil
@@ -27,3 +27,20 @@ func (tree *figTree) FigFlesh(name string) Flesh {
 	value := tree.useValue(tree.from(name))
 	return value.Flesh()
 }
+
+// ripen copies the settled value of every fig into the structs, semaphores and watchers that follow it
+func (tree *figTree) ripen() {
+	tree.mu.Lock()
+	defer tree.mu.Unlock()
+	for name := range tree.figs {
+		tree.ripenFig(name)
+	}
+}
+
+// ripenFig requires the figTree.mu to be locked and copies the value of name into the structs, semaphores, watchers and adopted flags that follow it
+func (tree *figTree) ripenFig(name string) {
+	tree.syncBinding(name)
+	tree.syncSemaphore(name)
+	tree.notifyObservers(name)
+	tree.syncAdopted(name)
+}
@@ -23,6 +23,10 @@ func (m Mutagenesis) Kind() string {
 		return "ListFlag|*ListFlag|[]string|*[]string"
 	case tMap:
 		return "MapFlag|*MapFlag|map[string]string|*map[string]string"
+	case tFile, tDirectory:
+		return "string|*string"
+	case tSemaphore:
+		return "int|*int"
 	default:
 		return string(m)
 	}
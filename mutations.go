@@ -3,7 +3,6 @@ package figtree
 import (
 	"errors"
 	"fmt"
-	"os"
 	"slices"
 	"strconv"
 	"strings"
@@ -53,23 +52,12 @@ func (tree *figTree) String(name string) *string {
 	}
 	s := value.Flesh().ToString()
 	if !tree.HasRule(RuleNoEnv) && !fruit.HasRule(RuleNoEnv) && !tree.ignoreEnv && tree.pollinate {
-		envs := os.Environ()
-		var e string
-		var ok bool
-		for _, env := range envs {
-			if strings.EqualFold(env, strings.ToUpper(name)) {
-				v := strings.Split(env, "=")
-				e = v[1]
-			}
-		}
-		if len(e) == 0 {
-			e, ok = os.LookupEnv(strings.ToUpper(name))
-		}
+		e, ok := tree.lookupEnv(name)
 		if ok && len(e) > 0 {
 			if !strings.EqualFold(strings.ToLower(e), strings.ToLower(s)) {
 				s = strings.Clone(e)
 				tree.mu.RUnlock()
-				tree.Store(fruit.Mutagenesis, name, e)
+				tree.pollinateFig(fruit.Mutagenesis, name, e)
 				tree.mu.RLock()
 				fruit = tree.figs[name]
 			}
@@ -111,7 +99,7 @@ func (tree *figTree) Bool(name string) *bool {
 	}
 	s := value.Flesh().ToBool()
 	if !tree.HasRule(RuleNoEnv) && !fruit.HasRule(RuleNoEnv) && !tree.ignoreEnv && tree.pollinate {
-		e := os.Getenv(name)
+		e, _ := tree.lookupEnv(name)
 		if len(e) > 0 {
 			pb, err := strconv.ParseBool(e)
 			if err != nil {
@@ -125,7 +113,7 @@ func (tree *figTree) Bool(name string) *bool {
 			if pb != s {
 				s = pb
 				tree.mu.RUnlock()
-				tree.Store(fruit.Mutagenesis, name, pb)
+				tree.pollinateFig(fruit.Mutagenesis, name, pb)
 				tree.mu.RLock()
 			}
 		}
@@ -171,7 +159,7 @@ func (tree *figTree) Int(name string) *int {
 	}
 	s := value.Flesh().ToInt()
 	if !tree.HasRule(RuleNoEnv) && !fruit.HasRule(RuleNoEnv) && !tree.ignoreEnv && tree.pollinate {
-		e := os.Getenv(name)
+		e, _ := tree.lookupEnv(name)
 		if len(e) > 0 {
 			h, err := strconv.Atoi(e)
 			if err != nil {
@@ -185,7 +173,7 @@ func (tree *figTree) Int(name string) *int {
 			if s != h {
 				s = h
 				tree.mu.RUnlock()
-				tree.Store(fruit.Mutagenesis, name, h)
+				tree.pollinateFig(fruit.Mutagenesis, name, h)
 				tree.mu.RLock()
 			}
 
@@ -227,7 +215,7 @@ func (tree *figTree) Int64(name string) *int64 {
 	}
 	s := value.Flesh().ToInt64()
 	if !tree.HasRule(RuleNoEnv) && !fruit.HasRule(RuleNoEnv) && !tree.ignoreEnv && tree.pollinate {
-		e := os.Getenv(name)
+		e, _ := tree.lookupEnv(name)
 		if len(e) > 0 {
 			h, err := strconv.ParseInt(e, 10, 64)
 			if err != nil {
@@ -241,7 +229,7 @@ func (tree *figTree) Int64(name string) *int64 {
 			if s != h {
 				s = h
 				tree.mu.RUnlock()
-				tree.Store(fruit.Mutagenesis, name, h)
+				tree.pollinateFig(fruit.Mutagenesis, name, h)
 				tree.mu.RLock()
 			}
 
@@ -283,7 +271,7 @@ func (tree *figTree) Float64(name string) *float64 {
 	}
 	s := value.Flesh().ToFloat64()
 	if !tree.HasRule(RuleNoEnv) && !fruit.HasRule(RuleNoEnv) && !tree.ignoreEnv && tree.pollinate {
-		e := os.Getenv(name)
+		e, _ := tree.lookupEnv(name)
 		if len(e) > 0 {
 			h, err := strconv.ParseFloat(e, 64)
 			if err != nil {
@@ -297,7 +285,7 @@ func (tree *figTree) Float64(name string) *float64 {
 			if s != h {
 				s = h
 				tree.mu.RUnlock()
-				tree.Store(fruit.Mutagenesis, name, h)
+				tree.pollinateFig(fruit.Mutagenesis, name, h)
 				tree.mu.RLock()
 			}
 
@@ -351,7 +339,7 @@ func (tree *figTree) Duration(name string) *time.Duration {
 		return nil
 	}
 	if !tree.HasRule(RuleNoEnv) && !fruit.HasRule(RuleNoEnv) && !tree.ignoreEnv && tree.pollinate {
-		e := os.Getenv(name)
+		e, _ := tree.lookupEnv(name)
 		if len(e) > 0 {
 			h, err := time.ParseDuration(e)
 			if err != nil {
@@ -365,7 +353,7 @@ func (tree *figTree) Duration(name string) *time.Duration {
 			if h != d {
 				d = h
 				tree.mu.RUnlock()
-				tree.Store(fruit.Mutagenesis, name, h)
+				tree.pollinateFig(fruit.Mutagenesis, name, h)
 				tree.mu.RLock()
 			}
 		}
@@ -418,7 +406,7 @@ func (tree *figTree) UnitDuration(name string) *time.Duration {
 		return nil
 	}
 	if !tree.HasRule(RuleNoEnv) && !fruit.HasRule(RuleNoEnv) && !tree.ignoreEnv && tree.pollinate {
-		e := os.Getenv(name)
+		e, _ := tree.lookupEnv(name)
 		if len(e) > 0 {
 			h, err := time.ParseDuration(e)
 			if err != nil {
@@ -432,7 +420,7 @@ func (tree *figTree) UnitDuration(name string) *time.Duration {
 			if h != d {
 				d = h
 				tree.mu.RUnlock()
-				tree.Store(fruit.Mutagenesis, name, h)
+				tree.pollinateFig(fruit.Mutagenesis, name, h)
 				tree.mu.RLock()
 			}
 		}
@@ -497,14 +485,14 @@ func (tree *figTree) List(name string) *[]string {
 		return nil
 	}
 	if !tree.HasRule(RuleNoEnv) && !fruit.HasRule(RuleNoEnv) && !tree.ignoreEnv && tree.pollinate {
-		e := os.Getenv(name)
+		e, _ := tree.lookupEnv(name)
 		if len(e) > 0 {
 			i := strings.Split(e, ListSeparator)
 			if len(i) == 0 {
 				v = []string{}
 			} else if !slices.Equal(v, i) {
 				tree.mu.RUnlock()
-				tree.Store(fruit.Mutagenesis, name, i)
+				tree.pollinateFig(fruit.Mutagenesis, name, i)
 				tree.mu.RLock()
 				value, err := tree.from(name)
 				if err != nil {
@@ -619,7 +607,7 @@ func (tree *figTree) Map(name string) *map[string]string {
 		return nil
 	}
 	if !tree.HasRule(RuleNoEnv) && !fruit.HasRule(RuleNoEnv) && !tree.ignoreEnv && tree.pollinate {
-		e := os.Getenv(name)
+		e, _ := tree.lookupEnv(name)
 		if len(e) > 0 {
 			i := strings.Split(e, MapSeparator)
 			if len(i) == 0 {
@@ -645,7 +633,7 @@ func (tree *figTree) Map(name string) *map[string]string {
 				}
 				if !equal {
 					tree.mu.RUnlock()
-					tree.Store(fruit.Mutagenesis, name, newMap)
+					tree.pollinateFig(fruit.Mutagenesis, name, newMap)
 					tree.mu.RLock()
 					value, err := tree.from(name)
 					if err != nil {
@@ -705,3 +693,54 @@ func (tree *figTree) Map(name string) *map[string]string {
 	}
 	return &v
 }
+
+// File returns a pointer to the stored file path with mutation tracking
+func (tree *figTree) File(name string) *string {
+	return tree.pathOf(tFile, name)
+}
+
+// Directory returns a pointer to the stored directory path with mutation tracking
+func (tree *figTree) Directory(name string) *string {
+	return tree.pathOf(tDirectory, name)
+}
+
+// pathOf returns a pointer to the path stored by a tFile or tDirectory fig with mutation tracking
+func (tree *figTree) pathOf(mut Mutagenesis, name string) *string {
+	tree.mu.RLock()
+	defer tree.mu.RUnlock()
+	name = tree.resolveName(name)
+	fruit, ok := tree.figs[name]
+	if !ok || fruit == nil {
+		return nil
+	}
+	err := fruit.runCallbacks(tree, CallbackBeforeRead)
+	if err != nil {
+		fruit.Error = errors.Join(fruit.Error, err)
+		tree.figs[name] = fruit
+		return &zeroString
+	}
+	value, err := tree.from(name)
+	if err != nil {
+		fruit.Error = errors.Join(fruit.Error, err)
+		tree.figs[name] = fruit
+		return &zeroString
+	}
+	s := value.Flesh().ToString()
+	if !tree.HasRule(RuleNoEnv) && !fruit.HasRule(RuleNoEnv) && !tree.ignoreEnv && tree.pollinate {
+		e, ok := tree.lookupEnv(name)
+		if ok && len(e) > 0 && e != s {
+			s = strings.Clone(e)
+			tree.mu.RUnlock()
+			tree.pollinateFig(mut, name, e)
+			tree.mu.RLock()
+			fruit = tree.figs[name]
+		}
+	}
+	err = fruit.runCallbacks(tree, CallbackAfterRead)
+	if err != nil {
+		fruit.Error = errors.Join(fruit.Error, err)
+		tree.figs[name] = fruit
+		return &zeroString
+	}
+	return &s
+}
@@ -15,7 +15,6 @@ func (tree *figTree) NewString(name string, value string, usage string) Plant {
 		tree.problems = append(tree.problems, fmt.Errorf("name '%s' already exists", name))
 		return tree
 	}
-	tree.activateFlagSet()
 	vPtr := &Value{
 		Value:      value,
 		Mutagensis: tString,
@@ -29,7 +28,7 @@ func (tree *figTree) NewString(name string, value string, usage string) Plant {
 		Mutations:   make([]Mutation, 0),
 		Validators:  make([]FigValidatorFunc, 0),
 		Callbacks:   make([]Callback, 0),
-		Rules:       make([]RuleKind, 0),
+		Rules:       tree.branchRules(name),
 	}
 	tree.figs[name] = def
 	if _, exists := tree.withered[name]; !exists {
@@ -51,7 +50,6 @@ func (tree *figTree) NewBool(name string, value bool, usage string) Plant {
 		tree.problems = append(tree.problems, fmt.Errorf("name '%s' already exists", name))
 		return tree
 	}
-	tree.activateFlagSet()
 	v := &Value{
 		Value:      value,
 		Mutagensis: tBool,
@@ -65,7 +63,7 @@ func (tree *figTree) NewBool(name string, value bool, usage string) Plant {
 		Mutations:   make([]Mutation, 0),
 		Validators:  make([]FigValidatorFunc, 0),
 		Callbacks:   make([]Callback, 0),
-		Rules:       make([]RuleKind, 0),
+		Rules:       tree.branchRules(name),
 	}
 	tree.figs[name] = def
 	if _, exists := tree.withered[name]; !exists {
@@ -87,7 +85,6 @@ func (tree *figTree) NewInt(name string, value int, usage string) Plant {
 		tree.problems = append(tree.problems, fmt.Errorf("name '%s' already exists", name))
 		return tree
 	}
-	tree.activateFlagSet()
 	v := &Value{
 		Value:      value,
 		Mutagensis: tInt,
@@ -101,7 +98,7 @@ func (tree *figTree) NewInt(name string, value int, usage string) Plant {
 		Mutations:   make([]Mutation, 0),
 		Validators:  make([]FigValidatorFunc, 0),
 		Callbacks:   make([]Callback, 0),
-		Rules:       make([]RuleKind, 0),
+		Rules:       tree.branchRules(name),
 	}
 	tree.figs[name] = def
 	if _, exists := tree.withered[name]; !exists {
@@ -123,7 +120,6 @@ func (tree *figTree) NewInt64(name string, value int64, usage string) Plant {
 		tree.problems = append(tree.problems, fmt.Errorf("name '%s' already exists", name))
 		return tree
 	}
-	tree.activateFlagSet()
 	v := &Value{
 		Value:      value,
 		Mutagensis: tInt64,
@@ -137,7 +133,7 @@ func (tree *figTree) NewInt64(name string, value int64, usage string) Plant {
 		Mutations:   make([]Mutation, 0),
 		Validators:  make([]FigValidatorFunc, 0),
 		Callbacks:   make([]Callback, 0),
-		Rules:       make([]RuleKind, 0),
+		Rules:       tree.branchRules(name),
 	}
 	tree.figs[name] = def
 	if _, exists := tree.withered[name]; !exists {
@@ -159,7 +155,6 @@ func (tree *figTree) NewFloat64(name string, value float64, usage string) Plant
 		tree.problems = append(tree.problems, fmt.Errorf("name '%s' already exists", name))
 		return tree
 	}
-	tree.activateFlagSet()
 	v := &Value{
 		Value:      value,
 		Mutagensis: tFloat64,
@@ -173,7 +168,7 @@ func (tree *figTree) NewFloat64(name string, value float64, usage string) Plant
 		Mutations:   make([]Mutation, 0),
 		Validators:  make([]FigValidatorFunc, 0),
 		Callbacks:   make([]Callback, 0),
-		Rules:       make([]RuleKind, 0),
+		Rules:       tree.branchRules(name),
 	}
 	tree.figs[name] = def
 	if _, exists := tree.withered[name]; !exists {
@@ -195,7 +190,6 @@ func (tree *figTree) NewDuration(name string, value time.Duration, usage string)
 		tree.problems = append(tree.problems, fmt.Errorf("name '%s' already exists", name))
 		return tree
 	}
-	tree.activateFlagSet()
 	v := &Value{
 		Value:      value,
 		Mutagensis: tDuration,
@@ -209,7 +203,7 @@ func (tree *figTree) NewDuration(name string, value time.Duration, usage string)
 		Mutations:   make([]Mutation, 0),
 		Validators:  make([]FigValidatorFunc, 0),
 		Callbacks:   make([]Callback, 0),
-		Rules:       make([]RuleKind, 0),
+		Rules:       tree.branchRules(name),
 	}
 	tree.figs[name] = def
 	if _, exists := tree.withered[name]; !exists {
@@ -231,7 +225,6 @@ func (tree *figTree) NewUnitDuration(name string, value, units time.Duration, us
 		tree.problems = append(tree.problems, fmt.Errorf("name '%s' already exists", name))
 		return tree
 	}
-	tree.activateFlagSet()
 	v := &Value{
 		Value:      value * units,
 		Mutagensis: tUnitDuration,
@@ -245,7 +238,7 @@ func (tree *figTree) NewUnitDuration(name string, value, units time.Duration, us
 		Mutations:   make([]Mutation, 0),
 		Validators:  make([]FigValidatorFunc, 0),
 		Callbacks:   make([]Callback, 0),
-		Rules:       make([]RuleKind, 0),
+		Rules:       tree.branchRules(name),
 	}
 	tree.figs[name] = def
 	if _, exists := tree.withered[name]; !exists {
@@ -270,7 +263,6 @@ func (tree *figTree) NewList(name string, value []string, usage string) Plant {
 		tree.problems = append(tree.problems, fmt.Errorf("name '%s' already exists", name))
 		return tree
 	}
-	tree.activateFlagSet()
 	v := &Value{
 		Value:      ListFlag{values: value},
 		Mutagensis: tList,
@@ -284,7 +276,7 @@ func (tree *figTree) NewList(name string, value []string, usage string) Plant {
 		Mutations:   make([]Mutation, 0),
 		Validators:  make([]FigValidatorFunc, 0),
 		Callbacks:   make([]Callback, 0),
-		Rules:       make([]RuleKind, 0),
+		Rules:       tree.branchRules(name),
 	}
 	tree.figs[name] = def
 	if _, exists := tree.withered[name]; !exists {
@@ -314,7 +306,6 @@ func (tree *figTree) NewMap(name string, value map[string]string, usage string)
 		tree.problems = append(tree.problems, fmt.Errorf("name '%s' already exists", name))
 		return tree
 	}
-	tree.activateFlagSet()
 	v := &Value{
 		Value:      MapFlag{values: value},
 		Mutagensis: tMap,
@@ -328,7 +319,7 @@ func (tree *figTree) NewMap(name string, value map[string]string, usage string)
 		Mutations:   make([]Mutation, 0),
 		Validators:  make([]FigValidatorFunc, 0),
 		Callbacks:   make([]Callback, 0),
-		Rules:       make([]RuleKind, 0),
+		Rules:       tree.branchRules(name),
 	}
 	tree.figs[name] = def
 	if _, exists := tree.withered[name]; !exists {
@@ -347,3 +338,48 @@ func (tree *figTree) NewMap(name string, value map[string]string, usage string)
 	}
 	return tree
 }
+
+// NewFile registers a new file path with validator and withered support
+func (tree *figTree) NewFile(name, path, usage string) Plant {
+	return tree.newPath(tFile, name, path, usage)
+}
+
+// NewDirectory registers a new directory path with validator and withered support
+func (tree *figTree) NewDirectory(name, path, usage string) Plant {
+	return tree.newPath(tDirectory, name, path, usage)
+}
+
+// newPath registers a path based Mutagenesis like tFile or tDirectory on the figTree
+func (tree *figTree) newPath(mut Mutagenesis, name, path, usage string) Plant {
+	tree.mu.Lock()
+	defer tree.mu.Unlock()
+	name = strings.ToLower(name)
+	if _, exists := tree.figs[name]; exists {
+		tree.problems = append(tree.problems, fmt.Errorf("name '%s' already exists", name))
+		return tree
+	}
+	v := &Value{
+		Value:      path,
+		Mutagensis: mut,
+	}
+	tree.values.Store(name, v)
+	tree.flagSet.Var(v, name, usage)
+	def := &figFruit{
+		name:        name,
+		usage:       usage,
+		Mutagenesis: mut,
+		Mutations:   make([]Mutation, 0),
+		Validators:  make([]FigValidatorFunc, 0),
+		Callbacks:   make([]Callback, 0),
+		Rules:       tree.branchRules(name),
+	}
+	tree.figs[name] = def
+	if _, exists := tree.withered[name]; !exists {
+		tree.withered[name] = witheredFig{
+			name:        name,
+			Value:       *v,
+			Mutagenesis: mut,
+		}
+	}
+	return tree
+}
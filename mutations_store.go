@@ -9,30 +9,50 @@ import (
 )
 
 func (tree *figTree) Store(mut Mutagenesis, name string, value interface{}) Plant {
+	_ = tree.store(mut, name, value)
+	return tree
+}
+
+// store is Store that returns the error it records on the fig so that typed handles can surface it
+func (tree *figTree) store(mut Mutagenesis, name string, value interface{}) error {
+	return tree.storeFrom(mut, name, value, storedBy())
+}
+
+// storeFrom is store that records from as the Provenance of the new value
+func (tree *figTree) storeFrom(mut Mutagenesis, name string, value interface{}, from Provenance) error {
 	tree.mu.Lock()
 	defer tree.mu.Unlock()
 	name = tree.resolveName(name)
 	fruit, ok := tree.figs[name]
-	if !ok {
-		return tree
-	}
-	if fruit == nil {
-		return tree
+	if !ok || fruit == nil {
+		return fmt.Errorf("no fig named -%s", name)
 	}
 	if tree.HasRule(RulePreventChange) || fruit.HasRule(RulePreventChange) {
-		return tree
+		return fmt.Errorf("RulePreventChange: -%s cannot change", name)
 	}
 	if tree.angel.Load() {
-		tree.figs[name].Error = errors.Join(tree.figs[name].Error, fmt.Errorf("tree fruit is an angel so we cannot store %s inside %s", tree.MutagenesisOf(value), fruit.Mutagenesis))
-		return tree
+		err := fmt.Errorf("tree fruit is an angel so we cannot store %s inside %s", tree.MutagenesisOf(value), fruit.Mutagenesis)
+		tree.figs[name].Error = errors.Join(tree.figs[name].Error, err)
+		return err
 	}
 	mv := tree.MutagenesisOf(value)
 	if mv == tDuration && mut == tUnitDuration {
 		mv = tUnitDuration
 	}
+	if mv == tString && (mut == tFile || mut == tDirectory) {
+		mv = mut
+	}
+	if mv == tInt && mut == tSemaphore {
+		mv = mut
+		if err := tree.semaphoreAllows(fruit, name, value); err != nil {
+			tree.figs[name].Error = errors.Join(tree.figs[name].Error, err)
+			return err
+		}
+	}
 	if !strings.EqualFold(string(mv), string(fruit.Mutagenesis)) {
-		tree.figs[name].Error = errors.Join(tree.figs[name].Error, fmt.Errorf("will not store %s inside %s", tree.MutagenesisOf(value), fruit.Mutagenesis))
-		return tree
+		err := fmt.Errorf("will not store %s inside %s", tree.MutagenesisOf(value), fruit.Mutagenesis)
+		tree.figs[name].Error = errors.Join(tree.figs[name].Error, err)
+		return err
 	}
 	if _, exists := tree.withered[name]; !exists {
 		tree.withered[name] = witheredFig{
@@ -44,26 +64,28 @@ func (tree *figTree) Store(mut Mutagenesis, name string, value interface{}) Plan
 			Error:       fmt.Errorf("missing withered value for %s", name),
 		}
 	}
-	err := fruit.runCallbacks(tree, CallbackBeforeChange)
-	if err != nil {
-		fruit.Error = errors.Join(fruit.Error, err)
+	before := fruit.runCallbacks(tree, CallbackBeforeChange)
+	if before != nil {
+		fruit.Error = errors.Join(fruit.Error, before)
 		tree.figs[name] = fruit
 	}
 	changed, previous, current := tree.persist(fruit, mut, name, value)
 	if !changed {
-		return tree
+		return before
 	}
-	err = fruit.runCallbacks(tree, CallbackAfterChange)
+	err := fruit.runCallbacks(tree, CallbackAfterChange)
 	if err != nil {
 		fruit.Error = errors.Join(fruit.Error, err)
 	}
 	tree.figs[name] = fruit
+	tree.trace(name, from)
+	chain := tree.chainOf(name)
+	tree.ripenFig(name)
 	if tree.tracking && !tree.angel.Load() {
 		// Store holds tree.mu while sending on mutationsCh. If the channel buffer
 		// is full, this send will block, stalling other tree operations. Ensure the
 		// channel capacity (Harvest) is large enough or consume mutations promptly.
-		tree.mu.Unlock() // fixes classic "lock while sending to a channel whose consumer needs the lock"
-		tree.mutationsCh <- Mutation{
+		mutation := tree.redactMutation(Mutation{
 			Property:    name,
 			Mutagenesis: strings.ToLower(string(mut)),
 			Way:         "Store" + string(mut),
@@ -71,10 +93,19 @@ func (tree *figTree) Store(mut Mutagenesis, name string, value interface{}) Plan
 			New:         current,
 			When:        time.Now(),
 			Error:       err,
-		}
+			Provenance:  chain[len(chain)-1],
+		})
+		tree.mu.Unlock() // fixes classic "lock while sending to a channel whose consumer needs the lock"
+		tree.mutationsCh <- mutation
 		tree.mu.Lock() // allows for the defer method to capture the remainder of the functionality of Store()
 	}
-	return tree
+	derived, ierr := tree.interpolateFigs()
+	if len(derived) > 0 {
+		tree.mu.Unlock()
+		tree.sendMutations(derived)
+		tree.mu.Lock()
+	}
+	return errors.Join(before, err, ierr)
 }
 
 // StoreString replaces the name with the new value while issuing a Mutation if figTree.tracking is true
@@ -122,6 +153,16 @@ func (tree *figTree) StoreMap(name string, value map[string]string) Plant {
 	return tree.Store(tMap, name, value)
 }
 
+// StoreFile replaces the name with the new path while issuing a Mutation if figTree.tracking is true
+func (tree *figTree) StoreFile(name, path string) Plant {
+	return tree.Store(tFile, name, path)
+}
+
+// StoreDirectory replaces the name with the new path while issuing a Mutation if figTree.tracking is true
+func (tree *figTree) StoreDirectory(name, path string) Plant {
+	return tree.Store(tDirectory, name, path)
+}
+
 // persist requires the figTree.mu to be locked before using this func and is an internal func
 func (tree *figTree) persist(fruit *figFruit, mut Mutagenesis, name string, value interface{}) (changed bool, previous, current interface{}) {
 	if fruit.HasRule(RulePreventChange) {
@@ -468,7 +509,7 @@ func (tree *figTree) persist(fruit *figFruit, mut Mutagenesis, name string, valu
 		tree.values.Store(name, value)
 		tree.figs[name] = fruit
 		return old != current, old, current
-	case tInt:
+	case tInt, tSemaphore:
 		old, err := toInt(flesh)
 		if err != nil {
 			tree.figs[name].Error = errors.Join(tree.figs[name].Error, err)
@@ -522,6 +563,33 @@ func (tree *figTree) persist(fruit *figFruit, mut Mutagenesis, name string, valu
 		tree.values.Store(name, value)
 		tree.figs[name] = fruit
 		return !strings.EqualFold(old, current), old, current
+	case tFile, tDirectory:
+		old, err := toString(flesh)
+		if err != nil {
+			tree.figs[name].Error = errors.Join(tree.figs[name].Error, err)
+			return false, flesh, value
+		}
+		current, err := toString(value)
+		if err != nil {
+			tree.figs[name].Error = errors.Join(tree.figs[name].Error, err)
+			return false, old, value
+		}
+		valueAny, ok := tree.values.Load(name)
+		if !ok {
+			return false, flesh, value
+		}
+		value, ok := valueAny.(*Value)
+		if !ok {
+			return false, flesh, value
+		}
+		err = value.Assign(current)
+		if err != nil {
+			tree.figs[name].Error = errors.Join(tree.figs[name].Error, err)
+			return false, old, value
+		}
+		tree.values.Store(name, value)
+		tree.figs[name] = fruit
+		return old != current, old, current
 	case tBool:
 		old, err := toBool(flesh)
 		if err != nil {
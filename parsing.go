@@ -4,7 +4,6 @@ import (
 	"errors"
 	"fmt"
 	"log"
-	"os"
 	"regexp"
 	"sort"
 	"strconv"
@@ -102,7 +101,7 @@ func (tree *figTree) checkFigErrors() error {
 			return fmt.Errorf("invalid Mutagenesis (Type) for flag -%s", name)
 		}
 		switch fig.Mutagenesis {
-		case tString:
+		case tString, tFile, tDirectory:
 			_, e := toString(value)
 			if e != nil {
 				er := value.Assign(zeroString)
@@ -111,7 +110,7 @@ func (tree *figTree) checkFigErrors() error {
 				}
 				return ErrInvalidValue{name, e}
 			}
-		case tInt:
+		case tInt, tSemaphore:
 			_, e := toInt(value)
 			if e != nil {
 				er := value.Assign(zeroInt)
@@ -195,16 +194,30 @@ func (tree *figTree) checkFigErrors() error {
 
 // Parse uses figTree.flagSet to run flag.Parse() on the registered figs and returns nil for validated results
 func (tree *figTree) Parse() (err error) {
+	return tree.ParseArgs(tree.argv())
+}
+
+// ParseArgs is Parse with args in place of Options.Args or os.Args[1:]
+//
+// Example:
+//
+//	figs := figtree.Grow()
+//	figs.NewInt("port", 8080, "listen port")
+//	err := figs.ParseArgs([]string{"-port", "9090"})
+func (tree *figTree) ParseArgs(args []string) (err error) {
+	defer tree.ripen()
 	preloadErr := tree.preLoadOrParse()
 	if preloadErr != nil {
 		return preloadErr
 	}
 	if !tree.HasRule(RuleNoFlags) {
-		tree.activateFlagSet()
-		args := os.Args[1:]
 		if tree.filterTests {
 			args = filterTestFlags(args)
 		}
+		args, err = tree.posixArgs(args)
+		if err != nil {
+			return err
+		}
 		err = tree.flagSet.Parse(args)
 		if err != nil {
 			err2 := tree.checkFigErrors()
@@ -213,23 +226,55 @@ func (tree *figTree) Parse() (err error) {
 			}
 			return err
 		}
-		err = tree.loadFlagSet()
+		tree.captureFlags()
+		err = tree.selectCommand()
+		if err != nil {
+			return err
+		}
+		err = tree.bindArgs()
+		if err != nil {
+			return err
+		}
+		err = tree.resolve(map[Source]func() error{
+			SourceEnv:  tree.readEnvStep,
+			SourceFlag: tree.loadFlagSet,
+		})
 		if err != nil {
 			return err
 		}
-		tree.readEnv()
 		err = tree.applyWithered()
 		if err != nil {
 			return err
 		}
-		return tree.validateAll()
+		err = tree.interpolate()
+		if err != nil {
+			return err
+		}
+		tree.explainIfAsked()
+		err = tree.validateAll()
+		if err != nil {
+			return err
+		}
+		return tree.dispatch()
+	}
+	err = tree.readEnv()
+	if err != nil {
+		return err
 	}
-	tree.readEnv()
 	err = tree.applyWithered()
 	if err != nil {
 		return err
 	}
-	return tree.validateAll()
+	err = tree.interpolate()
+	if err != nil {
+		return err
+	}
+	tree.explainIfAsked()
+	err = tree.validateAll()
+	if err != nil {
+		return err
+	}
+	return tree.dispatch()
 }
 
 func (tree *figTree) applyWithered() error {
@@ -284,17 +329,23 @@ func (tree *figTree) applyWithered() error {
 	return nil
 }
 
-// ParseFile will check if filename is set and run loadFile on it.
+// ParseFile will check if filename is set and run loadFile on it before it reads the environment and validates the figs.
 func (tree *figTree) ParseFile(filename string) (err error) {
+	defer tree.ripen()
 	preloadErr := tree.preLoadOrParse()
 	if preloadErr != nil {
 		return preloadErr
 	}
 	if !tree.HasRule(RuleNoFlags) {
-		tree.activateFlagSet()
-		args := os.Args[1:]
+		args := tree.argv()
 		if tree.filterTests {
 			args = filterTestFlags(args)
+		}
+		args, err = tree.posixArgs(args)
+		if err != nil {
+			return err
+		}
+		if tree.filterTests {
 			err = tree.flagSet.Parse(args)
 			if err != nil {
 				err2 := tree.checkFigErrors()
@@ -308,14 +359,35 @@ func (tree *figTree) ParseFile(filename string) (err error) {
 		if err != nil {
 			return err
 		}
+		tree.captureFlags()
+		err = tree.selectCommand()
+		if err != nil {
+			return err
+		}
+		err = tree.bindArgs()
+		if err != nil {
+			return err
+		}
+	}
+	steps := map[Source]func() error{
+		SourceEnv:  tree.readEnvStep,
+		SourceFlag: tree.loadFlagSetStep,
 	}
-	err = tree.loadFlagSet()
+	if filename != "" {
+		steps[SourceFile] = func() error { return tree.loadFile(filename) }
+	}
+	err = tree.resolve(steps)
 	if err != nil {
 		return err
 	}
-	if filename != "" {
-		return tree.loadFile(filename)
+	err = tree.interpolate()
+	if err != nil {
+		return err
+	}
+	tree.explainIfAsked()
+	err = tree.validateAll()
+	if err != nil {
+		return err
 	}
-	tree.readEnv()
-	return tree.validateAll()
+	return tree.dispatch()
 }
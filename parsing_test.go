@@ -1,6 +1,7 @@
 package figtree
 
 import (
+	"os"
 	"path/filepath"
 	"testing"
 
@@ -13,7 +14,7 @@ func TestTree_Parse(t *testing.T) {
 }
 
 func TestTree_ParseFile(t *testing.T) {
-	exts := []string{"yaml", "json", "ini"}
+	exts := []string{"yaml", "json", "ini", "toml"}
 	for _, ext := range exts {
 		t.Run(ext, func(t *testing.T) {
 			p := filepath.Join(".", "test.config."+ext)
@@ -29,3 +30,64 @@ func TestTree_ParseFile(t *testing.T) {
 		})
 	}
 }
+
+func TestTree_ParseFile_Validate(t *testing.T) {
+	os.Args = []string{os.Args[0]}
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	assert.NoError(t, os.WriteFile(path, []byte("region: moon\n"), 0644))
+	figs := With(Options{Germinate: true, IgnoreEnvironment: true})
+	figs.NewString("region", "us-east", "region")
+	figs.WithValidator("region", AssureStringHasPrefix("us-"))
+	assert.Error(t, figs.ParseFile(path))
+
+	figs = With(Options{Germinate: true, EnvSource: MapEnv{"REGION": "us-west"}})
+	figs.NewString("region", "us-east", "region")
+	figs.WithValidator("region", AssureStringHasPrefix("us-"))
+	assert.NoError(t, figs.ParseFile(path))
+	assert.Equal(t, "us-west", *figs.String("region"))
+}
+
+func TestTree_ParseArgs(t *testing.T) {
+	os.Args = []string{os.Args[0], "-port", "1111"}
+	figs := With(Options{Germinate: true, IgnoreEnvironment: true})
+	figs.NewInt("port", 8080, "listen port")
+	figs.NewString("name", "app", "name")
+	assert.NoError(t, figs.ParseArgs([]string{"-port", "9090", "-test.v", "-name=tool"}))
+	assert.Equal(t, 9090, *figs.Int("port"))
+	assert.Equal(t, "tool", *figs.String("name"))
+
+	figs = With(Options{Germinate: true, IgnoreEnvironment: true, Args: []string{"-port", "7070"}})
+	figs.NewInt("port", 8080, "listen port")
+	assert.NoError(t, figs.Parse())
+	assert.Equal(t, 7070, *figs.Int("port"))
+
+	figs = With(Options{Germinate: true, IgnoreEnvironment: true, Args: []string{}})
+	figs.NewInt("port", 8080, "listen port")
+	assert.NoError(t, figs.Parse())
+	assert.Equal(t, 8080, *figs.Int("port"))
+
+	figs = With(Options{Germinate: true, IgnoreEnvironment: true})
+	figs.NewInt("port", 8080, "listen port")
+	assert.Error(t, figs.ParseArgs([]string{"-unknown"}))
+	os.Args = []string{os.Args[0]}
+}
+
+func TestTree_LoadWithArgs(t *testing.T) {
+	os.Args = []string{os.Args[0], "-port", "1111"}
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	assert.NoError(t, os.WriteFile(path, []byte("name: from-file\n"), 0644))
+	figs := With(Options{Germinate: true, IgnoreEnvironment: true, ConfigFile: path})
+	figs.NewInt("port", 8080, "listen port")
+	figs.NewString("name", "app", "name")
+	assert.NoError(t, figs.LoadWithArgs([]string{"-port", "9090"}))
+	assert.Equal(t, 9090, *figs.Int("port"))
+	assert.Equal(t, "from-file", *figs.String("name"))
+
+	figs = With(Options{Germinate: true, IgnoreEnvironment: true, Args: []string{"-port", "7070"}})
+	figs.NewInt("port", 8080, "listen port")
+	figs.NewString("name", "app", "name")
+	assert.NoError(t, figs.LoadFile(path))
+	assert.Equal(t, 7070, *figs.Int("port"))
+	assert.Equal(t, "from-file", *figs.String("name"))
+	os.Args = []string{os.Args[0]}
+}
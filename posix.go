@@ -0,0 +1,95 @@
+package figtree
+
+import (
+	"fmt"
+	"strings"
+)
+
+// posixArgs rewrites GNU style arguments into the -name=value form that figTree.flagSet parses when Options.POSIX is on
+//
+// Example with -v and -f as aliases of the bools verbose and force and -o as an alias of the string output:
+//
+//	-vf            ->  -v -f
+//	-vo out.txt    ->  -v -o out.txt
+//	-voout.txt     ->  -v -o=out.txt
+//	--no-verbose   ->  -verbose=false
+//	--output=x     ->  --output=x
+//
+// A single dash argument that names a flag like -verbose is kept as it is, so existing command lines still parse.
+// Every other single dash argument is a bundle of one letter flags where only the last one may take a value.
+// Arguments after -- and after the first argument that is not a flag are left alone.
+func (tree *figTree) posixArgs(args []string) ([]string, error) {
+	if !tree.posix {
+		return args, nil
+	}
+	tree.mu.RLock()
+	defer tree.mu.RUnlock()
+	out := make([]string, 0, len(args))
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+		if arg == "--" || len(arg) < 2 || arg[0] != '-' {
+			return append(out, args[i:]...), nil
+		}
+		if strings.HasPrefix(arg, "--") {
+			name, value, hasValue := strings.Cut(arg[2:], "=")
+			target, negated := strings.CutPrefix(name, "no-")
+			if !negated || tree.flagSet.Lookup(name) != nil {
+				out = append(out, arg)
+				if !hasValue && !tree.isBoolFlag(name) && i+1 < len(args) {
+					i++
+					out = append(out, args[i])
+				}
+				continue
+			}
+			if !tree.isBoolFlag(target) {
+				return nil, fmt.Errorf("flag --%s: -%s is not a Bool", name, target)
+			}
+			if hasValue {
+				return nil, fmt.Errorf("flag --%s does not take a value ; got %q", name, value)
+			}
+			out = append(out, "-"+target+"=false")
+			continue
+		}
+		name, _, hasValue := strings.Cut(arg[1:], "=")
+		if len(name) == 1 || name == "help" || tree.flagSet.Lookup(name) != nil {
+			out = append(out, arg)
+			if !hasValue && !tree.isBoolFlag(name) && i+1 < len(args) {
+				i++
+				out = append(out, args[i])
+			}
+			continue
+		}
+		shorts := arg[1:]
+		for j := 0; j < len(shorts); j++ {
+			short := shorts[j : j+1]
+			if tree.flagSet.Lookup(short) == nil {
+				return nil, fmt.Errorf("unknown shorthand flag '%s' in %s", short, arg)
+			}
+			if tree.isBoolFlag(short) {
+				out = append(out, "-"+short)
+				continue
+			}
+			if rest := strings.TrimPrefix(shorts[j+1:], "="); rest != "" {
+				out = append(out, "-"+short+"="+rest)
+				break
+			}
+			out = append(out, "-"+short)
+			if i+1 < len(args) {
+				i++
+				out = append(out, args[i])
+			}
+			break
+		}
+	}
+	return out, nil
+}
+
+// isBoolFlag requires the figTree.mu to be locked and reports whether the flag name takes no value
+func (tree *figTree) isBoolFlag(name string) bool {
+	f := tree.flagSet.Lookup(name)
+	if f == nil {
+		return false
+	}
+	b, ok := f.Value.(interface{ IsBoolFlag() bool })
+	return ok && b.IsBoolFlag()
+}
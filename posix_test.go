@@ -0,0 +1,70 @@
+package figtree
+
+import (
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func newPOSIXFigs() Plant {
+	figs := With(Options{Germinate: true, IgnoreEnvironment: true, POSIX: true})
+	figs.NewBool("verbose", false, "verbose output")
+	figs.NewBool("force", false, "overwrite files")
+	figs.NewBool("cache", true, "use the cache")
+	figs.NewString("output", "out.txt", "output file")
+	figs.NewInt("level", 1, "compression level")
+	figs.WithAlias("verbose", "v")
+	figs.WithAlias("force", "f")
+	figs.WithAlias("output", "o")
+	return figs
+}
+
+func TestTree_POSIX(t *testing.T) {
+	os.Args = []string{os.Args[0]}
+	tests := []struct {
+		name    string
+		args    []string
+		verbose bool
+		force   bool
+		cache   bool
+		output  string
+		level   int
+	}{
+		{"defaults", []string{}, false, false, true, "out.txt", 1},
+		{"bundled bools", []string{"-vf"}, true, true, true, "out.txt", 1},
+		{"bundled value next", []string{"-vo", "a.txt", "-f"}, true, true, true, "a.txt", 1},
+		{"bundled value attached", []string{"-voa.txt"}, true, false, true, "a.txt", 1},
+		{"bundled value equals", []string{"-vo=a.txt"}, true, false, true, "a.txt", 1},
+		{"long with equals", []string{"--output=b.txt", "--level=9"}, false, false, true, "b.txt", 9},
+		{"long with value", []string{"--output", "b.txt", "--verbose"}, true, false, true, "b.txt", 1},
+		{"negation", []string{"--no-cache", "--verbose"}, true, false, false, "out.txt", 1},
+		{"single dash long", []string{"-verbose", "-level", "3"}, true, false, true, "out.txt", 3},
+		{"terminator", []string{"-v", "--", "-f"}, true, false, true, "out.txt", 1},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			figs := newPOSIXFigs()
+			assert.NoError(t, figs.ParseArgs(tc.args))
+			assert.Equal(t, tc.verbose, *figs.Bool("verbose"))
+			assert.Equal(t, tc.force, *figs.Bool("force"))
+			assert.Equal(t, tc.cache, *figs.Bool("cache"))
+			assert.Equal(t, tc.output, *figs.String("output"))
+			assert.Equal(t, tc.level, *figs.Int("level"))
+		})
+	}
+
+	for _, args := range [][]string{{"-vx"}, {"--no-output"}, {"--no-cache=true"}} {
+		assert.Error(t, newPOSIXFigs().ParseArgs(args), args)
+	}
+	assert.Error(t, With(Options{Germinate: true, IgnoreEnvironment: true}).ParseArgs([]string{"-vf"}))
+}
+
+func TestTree_POSIX_Usage(t *testing.T) {
+	os.Args = []string{os.Args[0]}
+	usage := newPOSIXFigs().UsageString()
+	assert.Contains(t, usage, "\n-v, --[no-]verbose[=false]")
+	assert.Contains(t, usage, "\n-o, --output[=out.txt]")
+	assert.Contains(t, usage, "\n--level[=1]")
+	assert.Contains(t, usage, "\n--[no-]cache[=true]")
+}
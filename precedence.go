@@ -0,0 +1,131 @@
+package figtree
+
+import (
+	"flag"
+	"fmt"
+)
+
+// precedenceSources lists the sources that Options.Precedence can order
+var precedenceSources = []Source{SourceDefault, SourceFile, SourceEnv, SourceFlag}
+
+// orderOf returns precedence when it orders every Source of precedenceSources once, starting with SourceDefault
+//
+// An empty precedence is DefaultPrecedence. Any other order is recorded as a problem and DefaultPrecedence is used.
+func (tree *figTree) orderOf(precedence []Source) []Source {
+	if len(precedence) == 0 {
+		return append([]Source(nil), DefaultPrecedence...)
+	}
+	problems := len(tree.problems)
+	seen := make(map[Source]bool, len(precedence))
+	for _, source := range precedence {
+		known := false
+		for _, s := range precedenceSources {
+			known = known || s == source
+		}
+		switch {
+		case !known:
+			tree.problems = append(tree.problems, fmt.Errorf("Precedence: unknown source '%s'", source))
+		case seen[source]:
+			tree.problems = append(tree.problems, fmt.Errorf("Precedence: source '%s' is listed more than once", source))
+		}
+		seen[source] = true
+	}
+	for _, source := range precedenceSources {
+		if !seen[source] {
+			tree.problems = append(tree.problems, fmt.Errorf("Precedence: source '%s' is missing", source))
+		}
+	}
+	if precedence[0] != SourceDefault {
+		tree.problems = append(tree.problems, fmt.Errorf("Precedence: source '%s' must come first", SourceDefault))
+	}
+	if len(tree.problems) > problems {
+		return append([]Source(nil), DefaultPrecedence...)
+	}
+	return append([]Source(nil), precedence...)
+}
+
+// resolve runs the step of each Source in Options.Precedence from the lowest to the highest so the highest is applied last
+func (tree *figTree) resolve(steps map[Source]func() error) error {
+	for _, source := range tree.precedence {
+		step, ok := steps[source]
+		if !ok || step == nil {
+			continue
+		}
+		if err := step(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// rank returns the position of source in Options.Precedence or -1 when it is not ranked
+func (tree *figTree) rank(source Source) int {
+	for i, s := range tree.precedence {
+		if s == source {
+			return i
+		}
+	}
+	return -1
+}
+
+// outranked requires the figTree.mu to be locked and reports whether the current value of name came from a Source
+// that Options.Precedence ranks above source
+//
+// A value given by Store or Pollinate is not ranked, so any source can replace it. A value derived by interpolate
+// keeps the rank of the template it came from.
+func (tree *figTree) outranked(name string, source Source) bool {
+	fruit, ok := tree.figs[name]
+	if !ok || fruit == nil {
+		return false
+	}
+	for i := len(fruit.provenance) - 1; i >= 0; i-- {
+		if fruit.provenance[i].Source != SourceInterpolate {
+			return tree.rank(fruit.provenance[i].Source) > tree.rank(source)
+		}
+	}
+	return false
+}
+
+// captureFlags remembers the values of the flags given on the command line before any other source can change them
+//
+// The flagSet writes into the same Value as the fig, so loadFlagSet applies these values at the position of
+// SourceFlag in Options.Precedence.
+func (tree *figTree) captureFlags() {
+	tree.mu.Lock()
+	defer tree.mu.Unlock()
+	tree.flagged = make(map[string]interface{})
+	tree.flagSet.Visit(func(f *flag.Flag) {
+		value, ok := f.Value.(*Value)
+		if !ok || (value.Mutagensis != tList && value.Mutagensis != tMap) {
+			tree.flagged[f.Name] = f.Value.String()
+			return
+		}
+		raw, err := toMutagenesis(value.Mutagensis, value.Value)
+		if err != nil {
+			tree.flagged[f.Name] = f.Value.String()
+			return
+		}
+		tree.flagged[f.Name] = cloneRaw(raw)
+	})
+}
+
+// flagValue requires the figTree.mu to be locked and returns the value of f that captureFlags remembered or else f.Value
+func (tree *figTree) flagValue(f *flag.Flag) interface{} {
+	if value, ok := tree.flagged[f.Name]; ok {
+		return value
+	}
+	return f.Value
+}
+
+// readEnvStep is readEnv as a step of resolve
+func (tree *figTree) readEnvStep() error {
+	return tree.readEnv()
+}
+
+// loadFlagSetStep is loadFlagSet as a step of resolve that is skipped by RuleNoFlags
+func (tree *figTree) loadFlagSetStep() error {
+	if tree.HasRule(RuleNoFlags) {
+		return nil
+	}
+	return tree.loadFlagSet()
+}
@@ -0,0 +1,105 @@
+package figtree
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTree_Precedence(t *testing.T) {
+	os.Args = []string{os.Args[0]}
+	dir := t.TempDir()
+	path := filepath.Join(dir, "config.json")
+	assert.NoError(t, os.WriteFile(path, []byte(`{"name": "file"}`), 0644))
+	env := MapEnv{"NAME": "env"}
+	flags := []string{"-name", "flag"}
+
+	orders := []struct {
+		name       string
+		precedence []Source
+		load       string // winner of file, env and flag
+		parse      string // winner of env and flag
+		noEnv      string // winner of file and flag
+	}{
+		{"default", nil, "flag", "flag", "flag"},
+		{"flags last", []Source{SourceDefault, SourceFile, SourceEnv, SourceFlag}, "flag", "flag", "flag"},
+		{"env last", []Source{SourceDefault, SourceFile, SourceFlag, SourceEnv}, "env", "env", "flag"},
+		{"file last", []Source{SourceDefault, SourceEnv, SourceFlag, SourceFile}, "file", "flag", "file"},
+		{"env over file over flags", []Source{SourceDefault, SourceFlag, SourceFile, SourceEnv}, "env", "env", "file"},
+	}
+	for _, order := range orders {
+		t.Run(order.name, func(t *testing.T) {
+			grow := func(env EnvSource) Plant {
+				figs := With(Options{Germinate: true, EnvSource: env, ConfigFile: path, Args: flags, Precedence: order.precedence})
+				figs.NewString("name", "default", "name")
+				assert.Empty(t, figs.Problems())
+				return figs
+			}
+
+			figs := grow(env)
+			assert.NoError(t, figs.Load())
+			assert.Equal(t, order.load, *figs.String("name"), "Load")
+			assert.Equal(t, Source(order.load), figs.SourceOf("name").Source)
+
+			figs = grow(env)
+			assert.NoError(t, figs.LoadFile(path))
+			assert.Equal(t, order.load, *figs.String("name"), "LoadFile")
+
+			figs = grow(env)
+			assert.NoError(t, figs.ParseFile(path))
+			assert.Equal(t, order.load, *figs.String("name"), "ParseFile")
+			assert.Equal(t, Source(order.load), figs.SourceOf("name").Source)
+
+			figs = grow(env)
+			assert.NoError(t, figs.Parse())
+			assert.Equal(t, order.parse, *figs.String("name"), "Parse")
+
+			figs = grow(MapEnv{})
+			assert.NoError(t, figs.Load())
+			assert.Equal(t, order.noEnv, *figs.String("name"), "Load without env")
+
+			figs = grow(MapEnv{})
+			assert.NoError(t, figs.ParseFile(path))
+			assert.Equal(t, order.noEnv, *figs.String("name"), "ParseFile without env")
+
+			figs = grow(MapEnv{})
+			assert.NoError(t, figs.Parse())
+			assert.NoError(t, figs.ReadFrom(path))
+			assert.Equal(t, order.noEnv, *figs.String("name"), "ReadFrom after Parse")
+		})
+	}
+}
+
+func TestTree_Precedence_Reload(t *testing.T) {
+	os.Args = []string{os.Args[0]}
+	env := MapEnv{}
+	figs := With(Options{Germinate: true, EnvSource: env, Precedence: []Source{SourceDefault, SourceFile, SourceEnv, SourceFlag}})
+	figs.NewInt("port", 8080, "port")
+	figs.NewInt("workers", 1, "workers")
+	assert.NoError(t, figs.ParseArgs([]string{"-port", "9090"}))
+	env["PORT"] = "7070"
+	env["WORKERS"] = "4"
+	assert.NoError(t, figs.Reload())
+	assert.Equal(t, 9090, *figs.Int("port"))
+	assert.Equal(t, 4, *figs.Int("workers"))
+
+	figs.StoreInt("port", 1234)
+	assert.NoError(t, figs.Reload())
+	assert.Equal(t, 7070, *figs.Int("port"), "a stored value is not ranked")
+}
+
+func TestTree_Precedence_Problems(t *testing.T) {
+	os.Args = []string{os.Args[0]}
+	for _, precedence := range [][]Source{
+		{SourceDefault, SourceFile, SourceEnv},
+		{SourceDefault, SourceFile, SourceEnv, SourceFlag, SourceFile},
+		{SourceFile, SourceDefault, SourceEnv, SourceFlag},
+		{SourceDefault, SourceFile, SourceEnv, SourceStore},
+	} {
+		figs := With(Options{Germinate: true, IgnoreEnvironment: true, Precedence: precedence})
+		assert.NotEmpty(t, figs.Problems(), precedence)
+		assert.Equal(t, DefaultPrecedence, figs.(*figTree).precedence)
+	}
+}
@@ -0,0 +1,149 @@
+package figtree
+
+import (
+	"errors"
+	"path/filepath"
+	"strings"
+
+	check "github.com/andreimerlescu/checkfs"
+	"github.com/andreimerlescu/checkfs/file"
+)
+
+// loadLayers loads each path followed by its profile overlays so a later file overrides the keys of an earlier one
+func (tree *figTree) loadLayers(paths []string) error {
+	tree.mu.RLock()
+	profiles := tree.activeProfiles()
+	tree.mu.RUnlock()
+	files := make([]string, 0, len(paths))
+	for _, path := range paths {
+		files = append(files, layersOf(path, profiles)...)
+	}
+	if err := tree.mergeFiles(files); err != nil {
+		return err
+	}
+	for _, f := range files {
+		tree.rememberFile(f)
+	}
+	return nil
+}
+
+// mergeFiles loads files in order and lets layer merge the values that more than one of them gives
+func (tree *figTree) mergeFiles(files []string) error {
+	tree.mu.Lock()
+	tree.layered = make(map[string]bool)
+	tree.mu.Unlock()
+	defer func() {
+		tree.mu.Lock()
+		tree.layered = nil
+		tree.mu.Unlock()
+	}()
+	for _, f := range files {
+		if err := tree.loadFile(f); err != nil {
+			var failure ErrLoadFailure
+			if errors.As(err, &failure) {
+				return err // verify already names the file
+			}
+			return ErrLoadFailure{f, err}
+		}
+	}
+	return nil
+}
+
+// layer requires the figTree.mu to be locked and merges a Map or a List that an earlier file of loadLayers gave name
+// into incoming when PolicyMapAppend or PolicyListAppend is enabled
+func (tree *figTree) layer(name string, incoming interface{}) interface{} {
+	if tree.layered == nil {
+		return incoming
+	}
+	earlier := tree.layered[name]
+	tree.layered[name] = true
+	fruit, ok := tree.figs[name]
+	if !earlier || !ok || fruit == nil {
+		return incoming
+	}
+	current, err := tree.from(name)
+	if err != nil || current == nil {
+		return incoming
+	}
+	switch {
+	case fruit.Mutagenesis == tMap && PolicyMapAppend:
+		base, err := toStringMap(current.Value)
+		if err != nil {
+			return incoming
+		}
+		over, err := toStringMap(incoming)
+		if err != nil {
+			return incoming
+		}
+		merged := make(map[string]string, len(base)+len(over))
+		for k, v := range base {
+			merged[k] = v
+		}
+		for k, v := range over {
+			merged[k] = v
+		}
+		return merged
+	case fruit.Mutagenesis == tList && PolicyListAppend:
+		base, err := toStringSlice(current.Value)
+		if err != nil {
+			return incoming
+		}
+		over, err := toStringSlice(incoming)
+		if err != nil {
+			return incoming
+		}
+		return DeduplicateStrings(append(append([]string{}, base...), over...))
+	}
+	return incoming
+}
+
+// activeProfiles requires the figTree.mu to be locked and returns the profiles selected by the ConfigProfileFlag
+//
+// The environment variable of the ConfigProfileFlag replaces the flag when SourceEnv outranks SourceFlag in
+// Options.Precedence or the flag was not given. Without either, Options.Profiles is used.
+func (tree *figTree) activeProfiles() []string {
+	if _, ok := tree.figs[ConfigProfileFlag]; !ok || !tree.profiles {
+		return nil
+	}
+	selected := ""
+	if value, err := tree.from(ConfigProfileFlag); err == nil && value != nil {
+		selected, _ = toString(value.Value)
+	}
+	_, flagged := tree.flagged[ConfigProfileFlag]
+	if !flagged || tree.rank(SourceEnv) > tree.rank(SourceFlag) {
+		if !tree.ignoreEnv && !tree.HasRule(RuleNoEnv) {
+			if env, ok := tree.lookupEnv(ConfigProfileFlag); ok {
+				selected = env
+			}
+		}
+	}
+	profiles := make([]string, 0)
+	for _, profile := range strings.Split(selected, ",") {
+		if profile = strings.TrimSpace(profile); profile != "" {
+			profiles = append(profiles, profile)
+		}
+	}
+	return profiles
+}
+
+// layersOf returns path followed by the overlay of each profile that exists, like config.prod.yaml or .env.prod
+func layersOf(path string, profiles []string) []string {
+	layers := []string{path}
+	for _, profile := range profiles {
+		overlay := profilePath(path, profile)
+		if err := check.File(overlay, file.Options{Exists: true}); err == nil {
+			layers = append(layers, overlay)
+		}
+	}
+	return layers
+}
+
+// profilePath names the overlay of path for profile, config.yaml becomes config.prod.yaml and .env becomes .env.prod
+func profilePath(path, profile string) string {
+	base := strings.ToLower(filepath.Base(path))
+	if base == ".env" || strings.HasPrefix(base, ".env.") {
+		return path + "." + profile
+	}
+	ext := filepath.Ext(path)
+	return strings.TrimSuffix(path, ext) + "." + profile + ext
+}
@@ -0,0 +1,112 @@
+package figtree
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func writeLayers(t *testing.T, files map[string]string) string {
+	dir := t.TempDir()
+	for name, content := range files {
+		assert.NoError(t, os.WriteFile(filepath.Join(dir, name), []byte(content), 0644))
+	}
+	return dir
+}
+
+func TestTree_LoadFiles(t *testing.T) {
+	os.Args = []string{os.Args[0]}
+	dir := writeLayers(t, map[string]string{
+		"base.yaml": "name: app\nport: 8080\ndb:\n  host: localhost\n  user: admin\nhosts: [a, b]\nlabels:\n  team: core\n  tier: web\n",
+		"prod.json": `{"port": 443, "db": {"host": "db.prod"}, "hosts": ["c"], "labels": {"tier": "edge"}}`,
+	})
+	base, prod := filepath.Join(dir, "base.yaml"), filepath.Join(dir, "prod.json")
+	grow := func() Plant {
+		figs := With(Options{Germinate: true, IgnoreEnvironment: true})
+		figs.NewString("name", "", "name")
+		figs.NewInt("port", 0, "port")
+		figs.NewBranch("db").NewString("host", "", "db host").NewString("user", "", "db user")
+		figs.NewList("hosts", []string{}, "hosts")
+		figs.NewMap("labels", map[string]string{}, "labels")
+		return figs
+	}
+
+	figs := grow()
+	assert.NoError(t, figs.LoadFiles(base, prod))
+	assert.Equal(t, "app", *figs.String("name"))
+	assert.Equal(t, 443, *figs.Int("port"))
+	assert.Equal(t, "db.prod", *figs.String("db.host"))
+	assert.Equal(t, "admin", *figs.String("db.user"))
+	assert.Equal(t, []string{"c"}, *figs.List("hosts"))
+	assert.Equal(t, map[string]string{"tier": "edge"}, *figs.Map("labels"))
+	assert.Equal(t, prod, figs.SourceOf("port").Origin)
+	assert.Equal(t, base, figs.SourceOf("name").Origin)
+
+	PolicyListAppend, PolicyMapAppend = true, true
+	defer func() { PolicyListAppend, PolicyMapAppend = false, false }()
+	figs = grow()
+	assert.NoError(t, figs.LoadFiles(base, prod))
+	assert.Equal(t, []string{"a", "b", "c"}, *figs.List("hosts"))
+	assert.Equal(t, map[string]string{"team": "core", "tier": "edge"}, *figs.Map("labels"))
+
+	figs = grow()
+	missing := filepath.Join(dir, "missing.yaml")
+	err := figs.LoadFiles(base, missing)
+	var failure ErrLoadFailure
+	assert.True(t, errors.As(err, &failure))
+	assert.Equal(t, missing, failure.What)
+	assert.Equal(t, 8080, *figs.Int("port"))
+}
+
+func TestTree_Profiles(t *testing.T) {
+	os.Args = []string{os.Args[0]}
+	dir := writeLayers(t, map[string]string{
+		"config.yaml":         "name: app\nport: 8080\nlevel: info\n",
+		"config.prod.yaml":    "port: 443\n",
+		"config.staging.yaml": "port: 8443\nlevel: debug\n",
+	})
+	path := filepath.Join(dir, "config.yaml")
+	grow := func(opts Options) Plant {
+		opts.Germinate, opts.ConfigFile = true, path
+		if opts.EnvSource == nil {
+			opts.EnvSource = MapEnv{}
+		}
+		figs := With(opts)
+		figs.NewString("name", "", "name")
+		figs.NewInt("port", 0, "port")
+		figs.NewString("level", "", "level")
+		return figs
+	}
+
+	figs := grow(Options{})
+	assert.NoError(t, figs.Load())
+	assert.Equal(t, 8080, *figs.Int("port"))
+
+	figs = grow(Options{Profiles: []string{"base", "prod"}})
+	assert.NoError(t, figs.Load())
+	assert.Equal(t, 443, *figs.Int("port"))
+	assert.Equal(t, "info", *figs.String("level"))
+	assert.Equal(t, "base,prod", *figs.String(ConfigProfileFlag))
+
+	figs = grow(Options{Profiles: []string{"prod"}, Args: []string{"-profile=staging"}})
+	assert.NoError(t, figs.Load())
+	assert.Equal(t, 8443, *figs.Int("port"))
+	assert.Equal(t, "debug", *figs.String("level"))
+
+	figs = grow(Options{Profiles: []string{}, EnvPrefix: "APP", EnvSource: MapEnv{"APP_PROFILE": "prod,staging"}})
+	assert.NoError(t, figs.LoadFile(path))
+	assert.Equal(t, 8443, *figs.Int("port"))
+	assert.Equal(t, "app", *figs.String("name"))
+	assert.Equal(t, []string{path, filepath.Join(dir, "config.prod.yaml"), filepath.Join(dir, "config.staging.yaml")}, figs.(*figTree).loadedFiles)
+}
+
+func TestProfilePath(t *testing.T) {
+	assert.Equal(t, "/etc/app/config.prod.yaml", profilePath("/etc/app/config.yaml", "prod"))
+	assert.Equal(t, "config.prod.toml", profilePath("config.toml", "prod"))
+	assert.Equal(t, ".env.prod", profilePath(".env", "prod"))
+	assert.Equal(t, ".env.local.prod", profilePath(".env.local", "prod"))
+	assert.Equal(t, "app.prod.env", profilePath("app.env", "prod"))
+}
@@ -0,0 +1,169 @@
+package figtree
+
+import (
+	"fmt"
+	"path/filepath"
+	"reflect"
+	"runtime"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Source is the kind of place that gave a fig its value
+type Source string
+
+const (
+	SourceDefault     Source = "default"     // the value the fig was registered with
+	SourceFile        Source = "file"        // a config file read by Load, LoadFile, ParseFile, ReadFrom or Watch
+	SourceEnv         Source = "env"         // an environment variable read by Parse, Load or Reload
+	SourceFlag        Source = "flag"        // a command line flag
+	SourceArg         Source = "argument"    // a positional argument registered by NewArg or NewArgs
+	SourceStore       Source = "store"       // a call to Store or one of the Store<Mutagenesis> funcs
+	SourcePollinate   Source = "pollinate"   // an environment variable read by a Getter with Options.Pollinate
+	SourceInterpolate Source = "interpolate" // the references of a ${name}, ${env:NAME} or ${file:/path} template
+)
+
+// maxProvenance caps how many assignments are kept in the resolution chain of a fig
+const maxProvenance = 32
+
+// Provenance records where one assignment of a fig came from
+type Provenance struct {
+	Source Source
+	// Origin is the file path, environment variable, flag, argument or file:line of the Store caller
+	Origin string
+	// Key is the key inside the file at Origin
+	Key   string
+	Value interface{}
+	When  time.Time
+}
+
+// String renders the Provenance like file config.yaml [db.host], env DB_HOST or flag -port
+func (p Provenance) String() string {
+	switch {
+	case p.Origin == "":
+		return string(p.Source)
+	case p.Key != "":
+		return fmt.Sprintf("%s %s [%s]", p.Source, p.Origin, p.Key)
+	default:
+		return fmt.Sprintf("%s %s", p.Source, p.Origin)
+	}
+}
+
+// SourceOf returns the Provenance of the current value of name
+//
+// Example:
+//
+//	err := figs.Load()
+//	log.Printf("port=%d from %s", *figs.Int("port"), figs.SourceOf("port")) // port=9090 from env PORT
+func (tree *figTree) SourceOf(name string) Provenance {
+	tree.mu.RLock()
+	defer tree.mu.RUnlock()
+	chain := tree.chainOf(tree.resolveName(name))
+	if len(chain) == 0 {
+		return Provenance{}
+	}
+	return tree.maskProvenance(tree.resolveName(name), chain[len(chain)-1])
+}
+
+// Explain renders every fig with its value followed by each assignment that led to it, oldest first
+func (tree *figTree) Explain() string {
+	tree.mu.RLock()
+	defer tree.mu.RUnlock()
+	names := make([]string, 0, len(tree.figs))
+	for name := range tree.figs {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	var sb strings.Builder
+	_, _ = fmt.Fprintf(&sb, "Resolution of %s (powered by figtree %s):\n", filepath.Base(tree.flagSet.Name()), Version())
+	for _, name := range names {
+		chain := tree.chainOf(name)
+		if len(chain) == 0 {
+			continue
+		}
+		current := tree.maskProvenance(name, chain[len(chain)-1])
+		_, _ = fmt.Fprintf(&sb, "-%s = %v (%s)\n", name, current.Value, current)
+		for _, p := range chain {
+			_, _ = fmt.Fprintf(&sb, "    %-40s %v\n", p.String(), tree.masked(name, p.Value))
+		}
+	}
+	return sb.String()
+}
+
+// chainOf requires the figTree.mu to be locked and returns the default of name followed by its recorded assignments
+func (tree *figTree) chainOf(name string) []Provenance {
+	fruit, ok := tree.figs[name]
+	if !ok || fruit == nil {
+		return nil
+	}
+	withered := tree.withered[name]
+	def := Provenance{Source: SourceDefault, Value: figRaw(fruit.Mutagenesis, withered.Value.Value)}
+	return append([]Provenance{def}, fruit.provenance...)
+}
+
+// trace requires the figTree.mu to be locked and appends from with the current value of name to its resolution chain
+//
+// A repeat of the last assignment from the same place with the same value is not recorded again.
+func (tree *figTree) trace(name string, from Provenance) {
+	fruit, ok := tree.figs[name]
+	if !ok || fruit == nil {
+		return
+	}
+	if value, err := tree.from(name); err == nil && value != nil {
+		from.Value = figRaw(fruit.Mutagenesis, value.Value)
+	}
+	if from.When.IsZero() {
+		from.When = time.Now()
+	}
+	if n := len(fruit.provenance); n > 0 {
+		last := fruit.provenance[n-1]
+		if last.Source == from.Source && last.Origin == from.Origin && last.Key == from.Key && reflect.DeepEqual(last.Value, from.Value) {
+			return
+		}
+	}
+	fruit.provenance = append(fruit.provenance, from)
+	if len(fruit.provenance) > maxProvenance {
+		fruit.provenance = append([]Provenance(nil), fruit.provenance[len(fruit.provenance)-maxProvenance:]...)
+	}
+}
+
+// figRaw returns a copy of value converted to mut or value itself when it cannot be converted
+func figRaw(mut Mutagenesis, value interface{}) interface{} {
+	if raw, err := toMutagenesis(mut, value); err == nil {
+		return cloneRaw(raw)
+	}
+	return cloneRaw(value)
+}
+
+// storedBy returns the Provenance of a Store made by the first caller outside of the figtree package
+func storedBy() Provenance {
+	_, self, _, _ := runtime.Caller(0)
+	dir := filepath.Dir(self)
+	pcs := make([]uintptr, 16)
+	frames := runtime.CallersFrames(pcs[:runtime.Callers(2, pcs)])
+	for {
+		frame, more := frames.Next()
+		if filepath.Dir(frame.File) != dir || strings.HasSuffix(frame.File, "_test.go") {
+			return Provenance{Source: SourceStore, Origin: fmt.Sprintf("%s:%d", filepath.Base(frame.File), frame.Line)}
+		}
+		if !more {
+			return Provenance{Source: SourceStore}
+		}
+	}
+}
+
+// pollinateFig stores a value that a Getter read from the environment with Options.Pollinate
+func (tree *figTree) pollinateFig(mut Mutagenesis, name string, value interface{}) {
+	tree.mu.RLock()
+	env, _, _ := tree.lookupEnvName(name)
+	tree.mu.RUnlock()
+	_ = tree.storeFrom(mut, name, value, Provenance{Source: SourcePollinate, Origin: env})
+}
+
+// explainIfAsked prints Explain when Options.Explain registered the ConfigExplainFlag and it was set
+func (tree *figTree) explainIfAsked() {
+	if tree.explain && *tree.Bool(ConfigExplainFlag) {
+		fmt.Print(tree.Explain())
+	}
+}
@@ -0,0 +1,140 @@
+package figtree
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTree_SourceOf(t *testing.T) {
+	os.Args = []string{os.Args[0]}
+	dir := t.TempDir()
+	path := filepath.Join(dir, "config.yaml")
+	assert.NoError(t, os.WriteFile(path, []byte("host: file.local\nport: 8081\n"), 0644))
+	figs := With(Options{Germinate: true, EnvSource: MapEnv{"PORT": "9000"}, ConfigFile: path})
+	figs.NewString("host", "localhost", "server host")
+	figs.NewInt("port", 8080, "server port")
+	figs.NewBool("debug", false, "debug mode")
+	figs.NewString("name", "app", "app name")
+
+	assert.Equal(t, Provenance{Source: SourceDefault, Value: "localhost"}, figs.SourceOf("host"))
+	assert.Equal(t, Provenance{}, figs.SourceOf("missing"))
+
+	assert.NoError(t, figs.LoadWithArgs([]string{"-debug"}))
+	host := figs.SourceOf("host")
+	assert.Equal(t, SourceFile, host.Source)
+	assert.Equal(t, path, host.Origin)
+	assert.Equal(t, "host", host.Key)
+	assert.Equal(t, "file.local", host.Value)
+	assert.Equal(t, "file "+path+" [host]", host.String())
+	assert.Equal(t, SourceEnv, figs.SourceOf("port").Source)
+	assert.Equal(t, "env PORT", figs.SourceOf("port").String())
+	assert.Equal(t, 9000, figs.SourceOf("port").Value)
+	assert.Equal(t, "flag -debug", figs.SourceOf("debug").String())
+	assert.Equal(t, SourceDefault, figs.SourceOf("name").Source)
+
+	figs.StoreString("name", "changed")
+	name := figs.SourceOf("name")
+	assert.Equal(t, SourceStore, name.Source)
+	assert.True(t, strings.HasPrefix(name.Origin, "provenance_test.go:"), name.Origin)
+	assert.Equal(t, "changed", name.Value)
+
+	explain := figs.Explain()
+	assert.Contains(t, explain, "-port = 9000 (env PORT)\n")
+	lines := strings.Split(explain, "\n")
+	var port []string
+	for i, line := range lines {
+		if strings.HasPrefix(line, "-port ") {
+			port = lines[i+1 : i+4]
+		}
+	}
+	assert.Len(t, port, 3)
+	assert.Equal(t, []string{"default", "file " + path + " [port]", "env PORT"}, []string{
+		strings.Fields(port[0])[0],
+		strings.Join(strings.Fields(port[1])[:3], " "),
+		strings.Join(strings.Fields(port[2])[:2], " "),
+	})
+}
+
+func TestTree_SourceOf_Mutations(t *testing.T) {
+	os.Args = []string{os.Args[0]}
+	env := MapEnv{}
+	figs := With(Options{Germinate: true, Tracking: true, Harvest: 10, Pollinate: true, EnvSource: env})
+	figs.NewString("name", "app", "app name")
+	assert.NoError(t, figs.ParseArgs([]string{"-name", "flagged"}))
+	assert.Equal(t, "flag -name", figs.SourceOf("name").String())
+
+	figs.StoreString("name", "stored")
+	mutation := <-figs.Mutations()
+	assert.Equal(t, SourceStore, mutation.Provenance.Source)
+	assert.Equal(t, "stored", mutation.Provenance.Value)
+
+	env["NAME"] = "pollinated"
+	assert.Equal(t, "pollinated", *figs.String("name"))
+	assert.Equal(t, "pollinate NAME", figs.SourceOf("name").String())
+	mutation = <-figs.Mutations()
+	assert.Equal(t, SourcePollinate, mutation.Provenance.Source)
+}
+
+func TestTree_SourceOf_Args(t *testing.T) {
+	os.Args = []string{os.Args[0]}
+	dir := t.TempDir()
+	path := filepath.Join(dir, ".env")
+	assert.NoError(t, os.WriteFile(path, []byte("DB_HOST=dotenv.local\n"), 0644))
+	figs := With(Options{Germinate: true, IgnoreEnvironment: true})
+	figs.NewBranch("db").NewString("host", "localhost", "database host")
+	assert.NoError(t, figs.LoadFile(path))
+	assert.Equal(t, "file "+path+" [DB_HOST]", figs.SourceOf("db.host").String())
+
+	figs = With(Options{Germinate: true, IgnoreEnvironment: true, Explain: true})
+	figs.NewArg("source", tString, "input file")
+	assert.NoError(t, figs.ParseArgs([]string{"-config-explain=false", "data.csv"}))
+	assert.Equal(t, "argument <source>", figs.SourceOf("source").String())
+	assert.Contains(t, figs.UsageString(), "-config-explain")
+}
+
+func TestTree_Explain_LoadFile(t *testing.T) {
+	os.Args = []string{os.Args[0]}
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	assert.NoError(t, os.WriteFile(path, []byte("host: file.local\n"), 0644))
+	for name, load := range map[string]func(Plant) error{
+		"LoadFile":  func(figs Plant) error { return figs.LoadFile(path) },
+		"ParseFile": func(figs Plant) error { return figs.ParseFile(path) },
+	} {
+		figs := With(Options{Germinate: true, IgnoreEnvironment: true, Explain: true, Args: []string{"-config-explain"}})
+		figs.NewString("host", "localhost", "server host")
+		stdout := os.Stdout
+		r, w, err := os.Pipe()
+		assert.NoError(t, err)
+		os.Stdout = w
+		err = load(figs)
+		os.Stdout = stdout
+		assert.NoError(t, w.Close())
+		printed, readErr := io.ReadAll(r)
+		assert.NoError(t, readErr)
+		assert.NoError(t, err, name)
+		assert.Contains(t, string(printed), "-host = file.local (file "+path, name)
+	}
+}
+
+func TestTree_Explain_NoFlags(t *testing.T) {
+	os.Args = []string{os.Args[0]}
+	figs := With(Options{Germinate: true, Explain: true, EnvSource: MapEnv{"CONFIG_EXPLAIN": "true", "HOST": "env.local"}})
+	figs.NewString("host", "localhost", "server host")
+	figs.WithTreeRule(RuleNoFlags)
+	stdout := os.Stdout
+	r, w, err := os.Pipe()
+	assert.NoError(t, err)
+	os.Stdout = w
+	err = figs.ParseArgs([]string{})
+	os.Stdout = stdout
+	assert.NoError(t, w.Close())
+	printed, readErr := io.ReadAll(r)
+	assert.NoError(t, readErr)
+	assert.NoError(t, err)
+	assert.Contains(t, string(printed), "-host = env.local (env HOST)")
+}
@@ -1,5 +1,10 @@
 package figtree
 
+import (
+	"fmt"
+	"strings"
+)
+
 type RuleKind int
 
 const (
@@ -13,8 +18,46 @@ const (
 	RuleNoLists                   RuleKind = iota // RuleNoLists blocks NewList, StoreList, and List from being called on the Tree
 	RuleNoFlags                   RuleKind = iota // RuleNoFlags disables the flag package from the Tree
 	RuleNoEnv                     RuleKind = iota // RuleNoEnv skips over all os.Getenv related logic
+	RuleUseSmartChannels          RuleKind = iota // RuleUseSmartChannels refuses to shrink a Semaphore below its active holders
+	RuleSecretFiles               RuleKind = iota // RuleSecretFiles reads a value from the file named by NAME_FILE or a file:// value
+	RuleSecret                    RuleKind = iota // RuleSecret masks the value in usage, Mutations, errors, Problems and SaveTo
 )
 
+// ruleNames maps the lowercase name of each RuleKind without its Rule prefix to the RuleKind
+var ruleNames = map[string]RuleKind{
+	"preventchange":             RulePreventChange,
+	"paniconchange":             RulePanicOnChange,
+	"novalidations":             RuleNoValidations,
+	"nocallbacks":               RuleNoCallbacks,
+	"condemnedfromresurrection": RuleCondemnedFromResurrection,
+	"nomaps":                    RuleNoMaps,
+	"nolists":                   RuleNoLists,
+	"noflags":                   RuleNoFlags,
+	"noenv":                     RuleNoEnv,
+	"usesmartchannels":          RuleUseSmartChannels,
+	"secretfiles":               RuleSecretFiles,
+	"secret":                    RuleSecret,
+}
+
+// RuleFromName returns the RuleKind named like preventChange or RulePreventChange
+func RuleFromName(name string) (RuleKind, error) {
+	key := strings.TrimPrefix(strings.ToLower(strings.TrimSpace(name)), "rule")
+	if rule, ok := ruleNames[key]; ok {
+		return rule, nil
+	}
+	return RuleUndefined, fmt.Errorf("unknown rule %q", name)
+}
+
+// ruleKnown reports whether rule is a defined RuleKind other than RuleUndefined
+func ruleKnown(rule RuleKind) bool {
+	for _, known := range ruleNames {
+		if known == rule {
+			return true
+		}
+	}
+	return false
+}
+
 func (tree *figTree) HasRule(rule RuleKind) bool {
 	if rule == RuleUndefined {
 		return false
@@ -50,6 +93,7 @@ func (tree *figTree) WithRule(name string, rule RuleKind) Plant {
 	name = tree.resolveName(name)
 	fruit, exists := tree.figs[name]
 	if !exists || fruit == nil {
+		tree.problems = append(tree.problems, fmt.Errorf("WithRule: no fig named -%s", name))
 		return tree
 	}
 	fruit.Rules = append(fruit.Rules, rule)
@@ -1,63 +1,108 @@
 package figtree
 
 import (
+	"bytes"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
+	"strings"
 
+	"github.com/BurntSushi/toml"
 	"github.com/go-ini/ini"
 	"gopkg.in/yaml.v3"
 )
 
 func (tree *figTree) ReadFrom(path string) error {
+	defer tree.ripen()
 	_, fileErr := os.Stat(path)
 	if os.IsNotExist(fileErr) || os.IsPermission(fileErr) {
 		return fileErr
 	}
-	return tree.loadFile(path)
+	if err := tree.loadFile(path); err != nil {
+		return err
+	}
+	return tree.interpolate()
 }
 
 func (tree *figTree) SaveTo(path string) error {
-	var properties = make(map[string]interface{})
 	tree.mu.Lock()
 	defer tree.mu.Unlock()
+	properties, err := tree.properties(func(name string, value interface{}) (interface{}, bool, error) {
+		return value, !tree.isSecret(name), nil
+	})
+	if err != nil {
+		return err
+	}
+	return tree.writeProperties(path, properties)
+}
+
+// properties requires the figTree.mu to be locked and returns the value of every fig that keep returns true for,
+// replaced by the value keep returns
+func (tree *figTree) properties(keep func(name string, value interface{}) (interface{}, bool, error)) (map[string]interface{}, error) {
+	var properties = make(map[string]interface{})
 	for name, fig := range tree.figs {
+		if tree.isArg(name) || tree.isBuiltin(name) {
+			continue // positional arguments belong to the command line and the builtin figs to Options
+		}
 		valueAny, ok := tree.values.Load(name)
 		if !ok {
-			return errors.Join(fig.Error, fmt.Errorf("failed to load %s", fig.name))
+			return nil, errors.Join(fig.Error, fmt.Errorf("failed to load %s", fig.name))
 		}
 		_value, ok := valueAny.(*Value)
 		if !ok {
-			return errors.Join(fig.Error, fmt.Errorf("failed to cast %s as *Value ; got %T", fig.name, valueAny))
+			return nil, errors.Join(fig.Error, fmt.Errorf("failed to cast %s as *Value ; got %T", fig.name, valueAny))
 		}
+		var property interface{}
 		switch v := _value.Value.(type) {
 		case MapFlag:
-			properties[name] = v.values
+			property = v.values
 		case *MapFlag:
-			properties[name] = v.values
+			property = v.values
 		case ListFlag:
-			properties[name] = v.values
+			property = v.values
 		case *ListFlag:
-			properties[name] = v.values
+			property = v.values
 		default:
-			properties[name] = _value.Value
+			property = _value.Value
+		}
+		property, kept, err := keep(name, property)
+		if err != nil {
+			return nil, fmt.Errorf("-%s: %w", name, err)
+		}
+		if kept {
+			properties[name] = property
 		}
 	}
+	return properties, nil
+}
+
+// isBuiltin requires the figTree.mu to be locked and reports whether name is the ConfigExplainFlag or the
+// ConfigProfileFlag that Options registered rather than the application
+func (tree *figTree) isBuiltin(name string) bool {
+	return (tree.explain && name == ConfigExplainFlag) || (tree.profiles && name == ConfigProfileFlag)
+}
+
+// writeProperties requires the figTree.mu to be locked and writes properties to path in the format of its extension
+func (tree *figTree) writeProperties(path string, properties map[string]interface{}) error {
 	formatValue := func(val interface{}) string {
 		return fmt.Sprintf("%v", val)
 	}
+	if isDotenv(path) {
+		// dotenv files tend to hold secrets so only the owner can read them
+		return os.WriteFile(path, tree.dotenvBytes(properties), 0600)
+	}
 	ext := filepath.Ext(path)
 	switch ext {
 	case ".yaml", ".yml":
-		yamlBytes, yamlErr := yaml.Marshal(properties)
+		yamlBytes, yamlErr := yaml.Marshal(tree.nestBranches(properties))
 		if yamlErr != nil {
 			return yamlErr
 		}
 		return os.WriteFile(path, yamlBytes, 0644)
 	case ".json":
-		jsonBytes, jsonErr := json.MarshalIndent(properties, "", "  ")
+		jsonBytes, jsonErr := json.MarshalIndent(tree.nestBranches(properties), "", "  ")
 		if jsonErr != nil {
 			return jsonErr
 		}
@@ -75,10 +120,29 @@ func (tree *figTree) SaveTo(path string) error {
 					section.Key(sk).SetValue(formatValue(sv))
 				}
 			default:
+				if branch := tree.branchOf(key); branch != "" {
+					// NewKey keeps child sections like [db.replica] from writing into the inherited keys of [db]
+					if _, err := cfg.Section(branch).NewKey(strings.TrimPrefix(key, branch+"."), formatValue(value)); err != nil {
+						return err
+					}
+					continue
+				}
 				cfg.Section("").Key(key).SetValue(formatValue(value))
 			}
 		}
 		return cfg.SaveTo(path)
+	case ".toml":
+		for key, value := range properties {
+			if value == nil {
+				// TOML has no null so unset values are left out
+				delete(properties, key)
+			}
+		}
+		var buf bytes.Buffer
+		if err := toml.NewEncoder(&buf).Encode(tree.nestBranches(properties)); err != nil {
+			return err
+		}
+		return os.WriteFile(path, buf.Bytes(), 0644)
 	default:
 		return errors.New("invalid file extension provided")
 	}
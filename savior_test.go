@@ -4,6 +4,7 @@ import (
 	"os"
 	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -35,6 +36,20 @@ func TestFigTree_SaveTo(t *testing.T) {
 	assert.NoError(t, os.RemoveAll(testFile))
 }
 
+func TestFigTree_SaveTo_Builtin(t *testing.T) {
+	os.Args = []string{os.Args[0]}
+	path := filepath.Join(t.TempDir(), "out.yaml")
+	figs := With(Options{Germinate: true, IgnoreEnvironment: true, Explain: true, Profiles: []string{"dev"}})
+	figs.NewString("name", "fig", "name")
+	assert.NoError(t, figs.ParseArgs([]string{}))
+	assert.NoError(t, figs.SaveTo(path))
+	data, err := os.ReadFile(path)
+	assert.NoError(t, err)
+	assert.Contains(t, string(data), "name: fig")
+	assert.NotContains(t, string(data), ConfigExplainFlag)
+	assert.NotContains(t, string(data), ConfigProfileFlag)
+}
+
 func TestFigTree_SaveTo_MapRoundTrip(t *testing.T) {
 	path := filepath.Join(t.TempDir(), "out.yaml")
 
@@ -68,3 +83,73 @@ func TestFigTree_SaveTo_ListRoundTrip(t *testing.T) {
 	assert.Equal(t, []string{"one", "three", "two"}, result,
 		"ListFlag should be unwrapped before serialization — got %v", result)
 }
+
+func TestFigTree_TOML(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.toml")
+	contents := `name = "app"
+ports = [80, 443]
+labels = { env = "dev", team = "core" }
+started = 2024-01-02T03:04:05Z
+server.port = 8080
+
+[db]
+host = "db.local"
+timeout = "5s"
+
+[db.replica]
+host = "replica.local"
+
+[cache]
+size = 64
+
+[headers]
+accept = "json"
+`
+	assert.NoError(t, os.WriteFile(path, []byte(contents), 0644))
+
+	figs := With(Options{Germinate: true, IgnoreEnvironment: true})
+	figs.NewString("name", "", "name")
+	figs.NewList("ports", []string{}, "ports")
+	figs.NewInt("server.port", 0, "server port")
+	figs.NewMap("headers", map[string]string{}, "headers")
+	db := figs.NewBranch("db")
+	db.NewString("host", "", "database host")
+	db.NewDuration("timeout", time.Second, "database timeout")
+	db.NewBranch("replica").NewString("host", "", "replica host")
+	assert.NoError(t, figs.ReadFrom(path))
+
+	assert.Equal(t, "app", *figs.String("name"))
+	assert.Equal(t, []string{"443", "80"}, *figs.List("ports"))
+	assert.Equal(t, 8080, *figs.Int("server.port"))
+	assert.Equal(t, map[string]string{"accept": "json"}, *figs.Map("headers"))
+	assert.Equal(t, "db.local", *db.String("host"))
+	assert.Equal(t, 5*time.Second, *db.Duration("timeout"))
+	assert.Equal(t, "replica.local", *figs.String("db.replica.host"))
+
+	// unknown keys become figs like they do in a YAML file
+	assert.Equal(t, tMap, figs.MutagenesisOfFig("labels"))
+	assert.Equal(t, map[string]string{"env": "dev", "team": "core"}, *figs.Map("labels"))
+	assert.Equal(t, "2024-01-02T03:04:05Z", *figs.String("started"))
+	assert.Equal(t, int64(64), *figs.Int64("cache.size"))
+
+	saved := filepath.Join(t.TempDir(), "saved.toml")
+	assert.NoError(t, figs.SaveTo(saved))
+	figs2 := With(Options{Germinate: true, IgnoreEnvironment: true})
+	figs2.NewList("ports", []string{}, "ports")
+	figs2.NewInt("server.port", 0, "server port")
+	figs2.NewMap("headers", map[string]string{}, "headers")
+	db2 := figs2.NewBranch("db")
+	db2.NewString("host", "", "database host")
+	db2.NewDuration("timeout", time.Second, "database timeout")
+	assert.NoError(t, figs2.ReadFrom(saved))
+	assert.Equal(t, []string{"443", "80"}, *figs2.List("ports"))
+	assert.Equal(t, 8080, *figs2.Int("server.port"))
+	assert.Equal(t, map[string]string{"accept": "json"}, *figs2.Map("headers"))
+	assert.Equal(t, "db.local", *db2.String("host"))
+	assert.Equal(t, 5*time.Second, *db2.Duration("timeout"))
+
+	assert.Error(t, figs.ReadFrom(filepath.Join(".", "test.config.yaml.toml")))
+	broken := filepath.Join(t.TempDir(), "broken.toml")
+	assert.NoError(t, os.WriteFile(broken, []byte("name = \n"), 0644))
+	assert.Error(t, figs.ReadFrom(broken))
+}
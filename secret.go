@@ -0,0 +1,159 @@
+package figtree
+
+import (
+	"errors"
+	"sort"
+	"strings"
+)
+
+// NewSecret registers the String name with RuleSecret so its value is masked wherever the figTree renders it
+//
+// Example:
+//
+//	figs := figtree.Grow()
+//	figs.NewSecret("db-password", "database password")
+//	err := figs.Load() // DB_PASSWORD=hunter2
+//	dsn := fmt.Sprintf("postgres://app:%s@db/app", *figs.String("db-password"))
+//
+// A secret has no default. UsageString, Explain, SourceOf, Mutations, ErrorFor, Problems and the errors of
+// validateAll show SecretMask in place of its value and SaveTo leaves it out, while String returns the real value.
+// WithRule(name, RuleSecret) marks a fig of any other Mutagenesis the same way.
+func (tree *figTree) NewSecret(name, usage string) Plant {
+	tree.mu.RLock()
+	_, exists := tree.figs[strings.ToLower(name)]
+	tree.mu.RUnlock()
+	tree.NewString(name, "", usage)
+	if exists {
+		return tree
+	}
+	return tree.WithRule(name, RuleSecret)
+}
+
+// markSecret requires the figTree.mu to be locked and gives name RuleSecret, like a value read from an ENC[...] envelope
+func (tree *figTree) markSecret(name string) {
+	if fruit, ok := tree.figs[name]; ok && fruit != nil && !fruit.HasRule(RuleSecret) {
+		fruit.Rules = append(fruit.Rules, RuleSecret)
+	}
+}
+
+// minRedacted is the shortest secret value that redact searches for, since a shorter one would also match the
+// ordinary words of a message and reveal its own length
+const minRedacted = 4
+
+// secretError is an error whose message has every secret value replaced by SecretMask
+type secretError struct {
+	err error
+	msg string
+}
+
+func (e secretError) Error() string {
+	return e.msg
+}
+
+func (e secretError) Unwrap() error {
+	return e.err
+}
+
+// isSecret requires the figTree.mu to be locked and reports whether name has RuleSecret on itself or on the figTree
+func (tree *figTree) isSecret(name string) bool {
+	if tree.HasRule(RuleSecret) {
+		return true
+	}
+	fruit, ok := tree.figs[name]
+	return ok && fruit != nil && fruit.HasRule(RuleSecret)
+}
+
+// masked requires the figTree.mu to be locked and returns SecretMask in place of a value of name that is not empty
+// when name is secret
+func (tree *figTree) masked(name string, value interface{}) interface{} {
+	if !tree.isSecret(name) || value == nil {
+		return value
+	}
+	if s, err := toString(value); err == nil && (s == "" || s == "[]" || s == "{}") {
+		return value
+	}
+	return SecretMask
+}
+
+// maskProvenance requires the figTree.mu to be locked and returns p with its Value masked when name is secret
+func (tree *figTree) maskProvenance(name string, p Provenance) Provenance {
+	p.Value = tree.masked(name, p.Value)
+	return p
+}
+
+// redactMutation requires the figTree.mu to be locked and masks the values and the error of a Mutation of a secret
+func (tree *figTree) redactMutation(m Mutation) Mutation {
+	m.Old = tree.masked(m.Property, m.Old)
+	m.New = tree.masked(m.Property, m.New)
+	m.Provenance = tree.maskProvenance(m.Property, m.Provenance)
+	m.Error = tree.redact(m.Error)
+	return m
+}
+
+// secrets requires the figTree.mu to be locked and returns the current values of every secret fig, longest first
+func (tree *figTree) secrets() []string {
+	values := make([]string, 0)
+	for name, fruit := range tree.figs {
+		if fruit == nil || !tree.isSecret(name) {
+			continue
+		}
+		value, err := tree.from(name)
+		if err != nil || value == nil {
+			continue
+		}
+		switch raw := figRaw(fruit.Mutagenesis, value.Value).(type) {
+		case []string:
+			values = append(values, raw...)
+		case map[string]string:
+			for _, v := range raw {
+				values = append(values, v)
+			}
+		default:
+			if s, err := toString(raw); err == nil {
+				values = append(values, s)
+			}
+		}
+	}
+	sort.Slice(values, func(i, j int) bool { return len(values[i]) > len(values[j]) })
+	return values
+}
+
+// redact requires the figTree.mu to be locked and returns err with every secret value of at least minRedacted
+// characters in its message masked
+func (tree *figTree) redact(err error) error {
+	if err == nil {
+		return nil
+	}
+	msg := err.Error()
+	redacted := msg
+	for _, secret := range tree.secrets() {
+		if len(secret) >= minRedacted {
+			redacted = strings.ReplaceAll(redacted, secret, SecretMask)
+		}
+	}
+	if redacted == msg {
+		return err
+	}
+	return secretError{err: err, msg: redacted}
+}
+
+// maskedError requires the figTree.mu to be locked and returns err about the value of name with that value replaced
+// by SecretMask when name is secret, whatever the length of the value
+func (tree *figTree) maskedError(name string, err error) error {
+	if err == nil || !tree.isSecret(name) {
+		return err
+	}
+	var value ErrValue
+	var invalid ErrInvalidType
+	var rejected errRejected
+	switch {
+	case errors.As(err, &rejected):
+		return secretError{err: err, msg: errRejected{rejected.format, SecretMask}.Error()}
+	case errors.As(err, &value):
+		return secretError{err: err, msg: ErrValue{value.Way, SecretMask, value.Than}.Error()}
+	case errors.As(err, &invalid):
+		return secretError{err: err, msg: ErrInvalidType{invalid.Wanted, SecretMask}.Error()}
+	default:
+		return secretError{err: err, msg: "invalid value " + SecretMask}
+	}
+}
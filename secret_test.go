@@ -0,0 +1,106 @@
+package figtree
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTree_NewSecret(t *testing.T) {
+	os.Args = []string{os.Args[0]}
+	figs := With(Options{Germinate: true, EnvSource: MapEnv{"PASSWORD": "hunter2"}})
+	figs.NewSecret("password", "database password")
+	figs.NewString("token", "t0ps3cr3t", "api token").WithRule("token", RuleSecret)
+	figs.NewString("host", "localhost", "database host")
+	assert.NoError(t, figs.ParseArgs([]string{}))
+	assert.Equal(t, "hunter2", *figs.String("password"))
+	assert.Equal(t, "t0ps3cr3t", *figs.String("token"))
+
+	usage := figs.UsageString()
+	assert.NotContains(t, usage, "t0ps3cr3t")
+	assert.Contains(t, usage, "-token[="+SecretMask+"]")
+	assert.Contains(t, usage, "-host[=localhost]")
+
+	explain := figs.Explain()
+	assert.NotContains(t, explain, "hunter2")
+	assert.NotContains(t, explain, "t0ps3cr3t")
+	assert.Contains(t, explain, "-password = "+SecretMask+" (env PASSWORD)")
+	assert.Equal(t, SecretMask, figs.SourceOf("password").Value)
+	assert.Equal(t, "localhost", figs.SourceOf("host").Value)
+
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	assert.NoError(t, figs.SaveTo(path))
+	data, err := os.ReadFile(path)
+	assert.NoError(t, err)
+	assert.NotContains(t, string(data), "password")
+	assert.NotContains(t, string(data), "t0ps3cr3t")
+	assert.Contains(t, string(data), "localhost")
+}
+
+func TestTree_NewSecret_Mutations(t *testing.T) {
+	os.Args = []string{os.Args[0]}
+	figs := With(Options{Germinate: true, IgnoreEnvironment: true, Tracking: true, Harvest: 10})
+	figs.NewSecret("password", "database password")
+	figs.NewString("url", "postgres://app:${password}@db", "database url").WithRule("url", RuleSecret)
+	assert.NoError(t, figs.ParseArgs([]string{"-password", "hunter2"}))
+	assert.Equal(t, "postgres://app:hunter2@db", *figs.String("url"))
+
+	for len(figs.Mutations()) > 0 {
+		<-figs.Mutations() // the values given by ParseArgs
+	}
+	figs.StoreString("password", "rotated")
+	for _, want := range []string{"password", "url"} {
+		mutation := <-figs.Mutations()
+		assert.Equal(t, want, mutation.Property)
+		assert.Equal(t, SecretMask, mutation.Old)
+		assert.Equal(t, SecretMask, mutation.New)
+		assert.NotContains(t, fmt.Sprintf("%v", mutation.Provenance.Value), "rotated")
+	}
+	assert.Equal(t, "postgres://app:rotated@db", *figs.String("url"))
+}
+
+func TestTree_NewSecret_Errors(t *testing.T) {
+	os.Args = []string{os.Args[0]}
+	figs := With(Options{Germinate: true, IgnoreEnvironment: true})
+	figs.NewSecret("password", "database password")
+	figs.WithValidator("password", AssureStringHasPrefix("pw-"))
+	err := figs.ParseArgs([]string{"-password", "hunter2"})
+	assert.Error(t, err)
+	assert.NotContains(t, err.Error(), "hunter2")
+	assert.Contains(t, err.Error(), SecretMask)
+	assert.Equal(t, "hunter2", *figs.String("password"))
+
+	tree := figs.(*figTree)
+	tree.mu.Lock()
+	tree.problems = append(tree.problems, fmt.Errorf("watch: rejected %q", "hunter2"))
+	tree.mu.Unlock()
+	problems := figs.Problems()
+	assert.Len(t, problems, 1)
+	assert.Equal(t, fmt.Sprintf("watch: rejected %q", SecretMask), problems[0].Error())
+
+	figs = With(Options{Germinate: true, IgnoreEnvironment: true})
+	figs.NewSecret("password", "database password")
+	figs.WithValidator("password", AssureStringHasPrefix("pw-"))
+	err = figs.ParseArgs([]string{"-password", "a"})
+	assert.EqualError(t, err, `validation failed for password: string must have prefix "pw-", got "`+SecretMask+`"`)
+	tree = figs.(*figTree)
+	tree.mu.Lock()
+	tree.problems = append(tree.problems, fmt.Errorf("watch: rejected a value"))
+	tree.mu.Unlock()
+	assert.Equal(t, "watch: rejected a value", figs.Problems()[0].Error())
+
+	figs = With(Options{Germinate: true, IgnoreEnvironment: true})
+	figs.NewSecret("password", "database password")
+	figs.WithValidator("password", func(value interface{}) error {
+		return fmt.Errorf("bad value %v", value)
+	})
+	err = figs.ParseArgs([]string{"-password", "ab"})
+	assert.EqualError(t, err, "validation failed for password: invalid value "+SecretMask)
+
+	rule, err := RuleFromName("RuleSecret")
+	assert.NoError(t, err)
+	assert.Equal(t, RuleSecret, rule)
+}
@@ -0,0 +1,46 @@
+package figtree
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	check "github.com/andreimerlescu/checkfs"
+	"github.com/andreimerlescu/checkfs/file"
+)
+
+// secretFileScheme prefixes a config value that RuleSecretFiles reads from a file, like file:///run/secrets/db
+const secretFileScheme = "file://"
+
+// secretFiles requires the figTree.mu to be locked and reports whether RuleSecretFiles applies to name
+func (tree *figTree) secretFiles(name string) bool {
+	if tree.HasRule(RuleSecretFiles) {
+		return true
+	}
+	fruit, ok := tree.figs[name]
+	return ok && fruit != nil && fruit.HasRule(RuleSecretFiles)
+}
+
+// isSecretFile reports whether raw is a String value like file:///run/secrets/db
+func isSecretFile(raw interface{}) bool {
+	s, ok := raw.(string)
+	return ok && strings.HasPrefix(s, secretFileScheme)
+}
+
+// readSecretFile returns the contents of path without trailing newlines once checkfs confirms it is a regular file
+// no larger than SecretFileMaxSize and no more permissive than SecretFileMode
+func readSecretFile(path string) (string, error) {
+	err := check.File(path, file.Options{
+		Exists:             true,
+		IsLessThan:         SecretFileMaxSize + 1,
+		LessPermissiveThan: SecretFileMode,
+	})
+	if err != nil {
+		return "", fmt.Errorf("secret file: %w", err)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", fmt.Errorf("secret file: %w", err)
+	}
+	return strings.TrimRight(string(data), "\r\n"), nil
+}
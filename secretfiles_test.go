@@ -0,0 +1,82 @@
+package figtree
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTree_SecretFiles_Env(t *testing.T) {
+	os.Args = []string{os.Args[0]}
+	dir := t.TempDir()
+	secret := filepath.Join(dir, "db")
+	assert.NoError(t, os.WriteFile(secret, []byte("hunter2\n\n"), 0600))
+	env := MapEnv{"DB_PASSWORD_FILE": secret, "API_KEY_FILE": secret}
+	grow := func() Plant {
+		figs := With(Options{Germinate: true, EnvSource: env})
+		figs.NewBranch("db").NewString("password", "", "database password")
+		figs.NewString("api_key", "", "api key")
+		return figs
+	}
+
+	figs := grow().WithRule("db.password", RuleSecretFiles)
+	assert.NoError(t, figs.ParseArgs([]string{}))
+	assert.Equal(t, "hunter2", *figs.String("db.password"))
+	assert.Equal(t, "", *figs.String("api_key"), "the rule is opt-in per fig")
+	assert.Equal(t, "env DB_PASSWORD_FILE", figs.SourceOf("db.password").String())
+
+	assert.NoError(t, os.WriteFile(secret, []byte("rotated\n"), 0600))
+	assert.NoError(t, figs.Reload())
+	assert.Equal(t, "rotated", *figs.String("db.password"))
+
+	figs = grow().WithTreeRule(RuleSecretFiles)
+	env["API_KEY"] = "literal"
+	assert.NoError(t, figs.ParseArgs([]string{}))
+	assert.Equal(t, "rotated", *figs.String("db.password"))
+	assert.Equal(t, "literal", *figs.String("api_key"), "the variable wins over its _FILE")
+}
+
+func TestTree_SecretFiles_Checks(t *testing.T) {
+	os.Args = []string{os.Args[0]}
+	dir := t.TempDir()
+	open := filepath.Join(dir, "open")
+	assert.NoError(t, os.WriteFile(open, []byte("x"), 0600))
+	assert.NoError(t, os.Chmod(open, 0666))
+	large := filepath.Join(dir, "large")
+	assert.NoError(t, os.WriteFile(large, make([]byte, 2048), 0600))
+	defer func(size int64) { SecretFileMaxSize = size }(SecretFileMaxSize)
+	SecretFileMaxSize = 1024
+
+	for _, path := range []string{open, large, filepath.Join(dir, "missing")} {
+		figs := With(Options{Germinate: true, EnvSource: MapEnv{"TOKEN_FILE": path}}).WithTreeRule(RuleSecretFiles)
+		figs.NewString("token", "", "token")
+		err := figs.ParseArgs([]string{})
+		var failure ErrLoadFailure
+		assert.True(t, errors.As(err, &failure), path)
+		assert.Equal(t, "TOKEN_FILE", failure.What)
+	}
+}
+
+func TestTree_SecretFiles_Config(t *testing.T) {
+	os.Args = []string{os.Args[0]}
+	dir := t.TempDir()
+	secret := filepath.Join(dir, "token")
+	assert.NoError(t, os.WriteFile(secret, []byte("abc123\n"), 0400))
+	config := filepath.Join(dir, "config.yaml")
+	assert.NoError(t, os.WriteFile(config, []byte("token: file://"+secret+"\nplain: file://"+secret+"\n"), 0644))
+
+	figs := With(Options{Germinate: true, IgnoreEnvironment: true, ConfigFile: config})
+	figs.NewString("token", "", "token").WithRule("token", RuleSecretFiles)
+	figs.NewString("plain", "", "plain")
+	assert.NoError(t, figs.Load())
+	assert.Equal(t, "abc123", *figs.String("token"))
+	assert.Equal(t, "file://"+secret, *figs.String("plain"))
+
+	assert.NoError(t, os.Chmod(secret, 0600))
+	assert.NoError(t, os.WriteFile(secret, []byte("def456\n"), 0600))
+	assert.NoError(t, figs.Reload())
+	assert.Equal(t, "def456", *figs.String("token"))
+}
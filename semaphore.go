@@ -0,0 +1,244 @@
+package figtree
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+)
+
+// figSemaphore is a counting semaphore whose limit can be resized while holders are active
+type figSemaphore struct {
+	mu      sync.Mutex
+	cond    *sync.Cond
+	limit   int
+	holders int
+}
+
+func newFigSemaphore(limit int) *figSemaphore {
+	s := &figSemaphore{limit: limit}
+	s.cond = sync.NewCond(&s.mu)
+	return s
+}
+
+// Acquire blocks until the number of holders is below the limit and then holds the semaphore
+func (s *figSemaphore) Acquire() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for s.holders >= s.limit {
+		s.cond.Wait()
+	}
+	s.holders++
+}
+
+// AcquireContext is Acquire that gives up and returns ctx.Err() when ctx is done
+func (s *figSemaphore) AcquireContext(ctx context.Context) error {
+	stop := context.AfterFunc(ctx, func() {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		s.cond.Broadcast()
+	})
+	defer stop()
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for s.holders >= s.limit {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		s.cond.Wait()
+	}
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	s.holders++
+	return nil
+}
+
+// TryAcquire holds the semaphore if the number of holders is below the limit without blocking
+func (s *figSemaphore) TryAcquire() bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.holders >= s.limit {
+		return false
+	}
+	s.holders++
+	return true
+}
+
+// Release gives back a hold taken by Acquire, AcquireContext or TryAcquire
+func (s *figSemaphore) Release() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.holders == 0 {
+		return
+	}
+	s.holders--
+	s.cond.Broadcast()
+}
+
+// Limit returns the number of holders allowed at once
+func (s *figSemaphore) Limit() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.limit
+}
+
+// Holders returns the number of active holders
+func (s *figSemaphore) Holders() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.holders
+}
+
+// resize changes the limit ; shrinking below the active holders makes Acquire wait until enough Release
+func (s *figSemaphore) resize(limit int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.limit == limit {
+		return
+	}
+	s.limit = limit
+	s.cond.Broadcast()
+}
+
+// Semaphore returns the Sema of name whose limit follows the value of the fig with mutation tracking
+//
+// Example:
+//
+//	figs.NewSemaphore("workers", 4, "concurrent workers")
+//	err := figs.Load() // -workers=8, WORKERS=8 or workers: 8 in config.yaml
+//	sem := figs.Semaphore("workers")
+//	sem.Acquire()
+//	defer sem.Release()
+func (tree *figTree) Semaphore(name string) Sema {
+	tree.mu.RLock()
+	defer tree.mu.RUnlock()
+	name = tree.resolveName(name)
+	fruit, ok := tree.figs[name]
+	if !ok || fruit == nil || fruit.Mutagenesis != tSemaphore {
+		return nil
+	}
+	sem, ok := tree.semaphores[name]
+	if !ok {
+		return nil
+	}
+	err := fruit.runCallbacks(tree, CallbackBeforeRead)
+	if err != nil {
+		fruit.Error = errors.Join(fruit.Error, err)
+		tree.figs[name] = fruit
+		return sem
+	}
+	value, err := tree.from(name)
+	if err != nil {
+		fruit.Error = errors.Join(fruit.Error, err)
+		tree.figs[name] = fruit
+		return sem
+	}
+	limit, err := toInt(value.Value)
+	if err == nil && limit >= 1 {
+		sem.resize(limit)
+	}
+	if !tree.HasRule(RuleNoEnv) && !fruit.HasRule(RuleNoEnv) && !tree.ignoreEnv && tree.pollinate {
+		e, ok := tree.lookupEnv(name)
+		if pl, perr := toInt(e); ok && perr == nil && pl != limit {
+			tree.mu.RUnlock()
+			tree.pollinateFig(tSemaphore, name, pl)
+			tree.mu.RLock()
+			fruit = tree.figs[name]
+		}
+	}
+	err = fruit.runCallbacks(tree, CallbackAfterRead)
+	if err != nil {
+		fruit.Error = errors.Join(fruit.Error, err)
+		tree.figs[name] = fruit
+	}
+	return sem
+}
+
+// NewSemaphore registers a new Semaphore whose limit can be assigned like an int with -name=4
+//
+// A limit below 1 would block every Acquire, so it is recorded in Problems and name is not registered. A limit below
+// 1 given later by a flag, the environment or a config file fails validateAll and the Semaphore keeps its last limit.
+func (tree *figTree) NewSemaphore(name string, limit int, usage string) Plant {
+	tree.mu.Lock()
+	defer tree.mu.Unlock()
+	name = strings.ToLower(name)
+	if _, exists := tree.figs[name]; exists {
+		tree.problems = append(tree.problems, fmt.Errorf("name '%s' already exists", name))
+		return tree
+	}
+	if limit < 1 {
+		tree.problems = append(tree.problems, fmt.Errorf("NewSemaphore: -%s needs a limit of at least 1 ; got %d", name, limit))
+		return tree
+	}
+	v := &Value{
+		Value:      limit,
+		Mutagensis: tSemaphore,
+	}
+	tree.values.Store(name, v)
+	tree.flagSet.Var(v, name, usage)
+	def := &figFruit{
+		name:        name,
+		usage:       usage,
+		Mutagenesis: tSemaphore,
+		Mutations:   make([]Mutation, 0),
+		Validators:  []FigValidatorFunc{AssureSemaphoreGreaterThan(0)},
+		Callbacks:   make([]Callback, 0),
+		Rules:       tree.branchRules(name),
+	}
+	tree.figs[name] = def
+	tree.semaphores[name] = newFigSemaphore(limit)
+	if _, exists := tree.withered[name]; !exists {
+		tree.withered[name] = witheredFig{
+			name:        name,
+			Value:       *v,
+			Mutagenesis: tSemaphore,
+		}
+	}
+	return tree
+}
+
+// StoreSemaphore resizes the Semaphore name to limit while issuing a Mutation if figTree.tracking is true
+//
+// With RuleUseSmartChannels the resize is refused and recorded in ErrorFor(name) while more
+// holders are active than the new limit allows. Without it the Semaphore shrinks immediately
+// and Acquire waits until enough holders Release.
+func (tree *figTree) StoreSemaphore(name string, limit int) Plant {
+	return tree.Store(tSemaphore, name, limit)
+}
+
+// semaphoreAllows requires the figTree.mu to be locked and returns an error if name cannot be resized to value
+func (tree *figTree) semaphoreAllows(fruit *figFruit, name string, value interface{}) error {
+	limit, err := toInt(value)
+	if err != nil {
+		return err
+	}
+	if limit < 1 {
+		return ErrValue{ErrWayBeAbove, limit, 0}
+	}
+	sem, ok := tree.semaphores[name]
+	if !ok || !(tree.HasRule(RuleUseSmartChannels) || fruit.HasRule(RuleUseSmartChannels)) {
+		return nil
+	}
+	if holders := sem.Holders(); holders > limit {
+		return fmt.Errorf("RuleUseSmartChannels: semaphore %s has %d holders ; cannot resize to %d", name, holders, limit)
+	}
+	return nil
+}
+
+// syncSemaphore requires the figTree.mu to be locked and resizes the Semaphore name to the value of its fig when it
+// is at least 1
+func (tree *figTree) syncSemaphore(name string) {
+	sem, ok := tree.semaphores[name]
+	if !ok {
+		return
+	}
+	value, err := tree.from(name)
+	if err != nil || value == nil {
+		return
+	}
+	if limit, err := toInt(value.Value); err == nil && limit >= 1 {
+		sem.resize(limit)
+	}
+}
@@ -0,0 +1,162 @@
+package figtree
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTree_NewSemaphore(t *testing.T) {
+	os.Args = []string{os.Args[0], "-workers", "3"}
+	figs := With(Options{Germinate: true, IgnoreEnvironment: true, Tracking: true, Harvest: 10})
+	figs.NewSemaphore("workers", 2, "concurrent workers")
+	figs.WithValidators("workers", AssureSemaphoreGreaterThan(0), AssureSemaphoreLessThan(10))
+	assert.NoError(t, figs.Parse())
+	assert.Equal(t, tSemaphore, figs.MutagenesisOfFig("workers"))
+	assert.Contains(t, figs.UsageString(), "[Semaphore]")
+
+	sem := figs.Semaphore("workers")
+	assert.NotNil(t, sem)
+	assert.Equal(t, 3, sem.Limit())
+	assert.True(t, sem.TryAcquire())
+	assert.True(t, sem.TryAcquire())
+	assert.True(t, sem.TryAcquire())
+	assert.False(t, sem.TryAcquire())
+	assert.Equal(t, 3, sem.Holders())
+
+	figs.StoreSemaphore("workers", 4)
+	mutation := <-figs.Mutations()
+	assert.Equal(t, "workers", mutation.Property)
+	assert.Equal(t, "StoreSemaphore", mutation.Way)
+	assert.Equal(t, 3, mutation.Old)
+	assert.Equal(t, 4, mutation.New)
+	assert.Equal(t, 4, sem.Limit())
+	assert.True(t, sem.TryAcquire())
+
+	figs.StoreSemaphore("workers", 1)
+	assert.Equal(t, 1, sem.Limit())
+	assert.False(t, sem.TryAcquire())
+	for i := 0; i < 4; i++ {
+		sem.Release()
+	}
+	assert.Equal(t, 0, sem.Holders())
+	sem.Release()
+	assert.Equal(t, 0, sem.Holders())
+
+	assert.Nil(t, figs.Semaphore("missing"))
+	os.Args = []string{os.Args[0]}
+}
+
+func TestTree_Semaphore_Resize(t *testing.T) {
+	os.Args = []string{os.Args[0]}
+	figs := With(Options{Germinate: true, IgnoreEnvironment: true})
+	figs.NewSemaphore("pool", 1, "pool size")
+	assert.NoError(t, figs.Parse())
+	sem := figs.Semaphore("pool")
+
+	sem.Acquire()
+	var acquired atomic.Int32
+	var wg sync.WaitGroup
+	for i := 0; i < 3; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			sem.Acquire()
+			acquired.Add(1)
+		}()
+	}
+	time.Sleep(20 * time.Millisecond)
+	assert.Equal(t, int32(0), acquired.Load())
+	figs.StoreSemaphore("pool", 4)
+	wg.Wait()
+	assert.Equal(t, int32(3), acquired.Load())
+	assert.Equal(t, 4, sem.Holders())
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	assert.ErrorIs(t, sem.AcquireContext(ctx), context.DeadlineExceeded)
+}
+
+func TestTree_Semaphore_SmartChannels(t *testing.T) {
+	os.Args = []string{os.Args[0]}
+	figs := With(Options{Germinate: true, IgnoreEnvironment: true})
+	figs.NewSemaphore("pool", 3, "pool size")
+	figs.WithRule("pool", RuleUseSmartChannels)
+	assert.NoError(t, figs.Parse())
+	sem := figs.Semaphore("pool")
+	sem.Acquire()
+	sem.Acquire()
+
+	figs.StoreSemaphore("pool", 1)
+	assert.Equal(t, 3, sem.Limit())
+	assert.ErrorContains(t, figs.ErrorFor("pool"), "RuleUseSmartChannels")
+
+	sem.Release()
+	figs.StoreSemaphore("pool", 1)
+	assert.Equal(t, 1, sem.Limit())
+}
+
+func TestTree_Semaphore_Reload(t *testing.T) {
+	os.Args = []string{os.Args[0]}
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	assert.NoError(t, os.WriteFile(path, []byte("pool: 5\n"), 0644))
+	figs := With(Options{Germinate: true, IgnoreEnvironment: true})
+	figs.NewSemaphore("pool", 1, "pool size")
+	sem := figs.Semaphore("pool")
+	assert.NoError(t, figs.LoadFile(path))
+	assert.Equal(t, 5, sem.Limit())
+	assert.Equal(t, 5, *figs.Int("pool"))
+}
+
+func TestTree_NewSemaphore_Limit(t *testing.T) {
+	os.Args = []string{os.Args[0]}
+	figs := With(Options{Germinate: true, IgnoreEnvironment: true})
+	figs.NewSemaphore("empty", 0, "never acquirable").NewSemaphore("negative", -2, "never acquirable")
+	assert.Len(t, figs.Problems(), 2)
+	assert.Error(t, figs.ErrorFor("empty"), "the semaphore is not registered")
+	assert.Error(t, figs.ErrorFor("negative"))
+	assert.NoError(t, figs.ParseArgs([]string{}))
+
+	for _, limit := range []string{"0", "-1"} {
+		figs = With(Options{Germinate: true, IgnoreEnvironment: true})
+		figs.NewSemaphore("workers", 2, "concurrent workers")
+		assert.Error(t, figs.ParseArgs([]string{"-workers", limit}), limit)
+		assert.Equal(t, 2, figs.Semaphore("workers").Limit(), limit)
+
+		figs = With(Options{Germinate: true, EnvSource: MapEnv{"WORKERS": limit}})
+		figs.NewSemaphore("workers", 2, "concurrent workers")
+		assert.Error(t, figs.Load(), limit)
+		assert.Equal(t, 2, figs.Semaphore("workers").Limit(), limit)
+
+		path := filepath.Join(t.TempDir(), "config.yaml")
+		assert.NoError(t, os.WriteFile(path, []byte("workers: "+limit+"\n"), 0644))
+		figs = With(Options{Germinate: true, IgnoreEnvironment: true})
+		figs.NewSemaphore("workers", 2, "concurrent workers")
+		assert.Error(t, figs.LoadFile(path), limit)
+		assert.Equal(t, 2, figs.Semaphore("workers").Limit(), limit)
+	}
+
+	figs = With(Options{Germinate: true, IgnoreEnvironment: true})
+	figs.NewSemaphore("workers", 2, "concurrent workers")
+	assert.NoError(t, figs.ParseArgs([]string{}))
+	figs.StoreSemaphore("workers", 0)
+	assert.Error(t, figs.ErrorFor("workers"))
+	assert.Equal(t, 2, figs.Semaphore("workers").Limit())
+}
+
+func TestAssureSemaphore(t *testing.T) {
+	assert.NoError(t, AssureSemaphoreIs(3)(3))
+	assert.Error(t, AssureSemaphoreIs(3)(4))
+	assert.NoError(t, AssureSemaphoreLessThan(3)(2))
+	assert.Error(t, AssureSemaphoreLessThan(3)(3))
+	assert.NoError(t, AssureSemaphoreGreaterThan(3)(4))
+	assert.Error(t, AssureSemaphoreGreaterThan(3)(3))
+	assert.NoError(t, AssureSemaphoreIs(2)(newFigSemaphore(2)))
+	assert.Error(t, AssureSemaphoreIs(2)("two"))
+}
@@ -0,0 +1,97 @@
+package figtree
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// signatureBlock starts the last line of a config file that embeds its own signature
+const signatureBlock = "# figtree-signature: "
+
+// SignFile writes the detached ed25519 signature of path into path plus SignatureSuffix
+//
+// Example:
+//
+//	err := figtree.SignFile("config.yaml", privateKey) // writes config.yaml.sig
+//	figs := figtree.With(figtree.Options{TrustedKeys: []ed25519.PublicKey{publicKey}})
+//	err = figs.LoadFile("config.yaml")
+func SignFile(path string, key ed25519.PrivateKey) error {
+	if len(key) != ed25519.PrivateKeySize {
+		return fmt.Errorf("invalid ed25519 private key of %d bytes", len(key))
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	signature := base64.StdEncoding.EncodeToString(ed25519.Sign(key, data))
+	return os.WriteFile(path+SignatureSuffix, []byte(signature+"\n"), 0644)
+}
+
+// SaveToSigned is SaveTo followed by SignFile so a figTree with Options.TrustedKeys can load path
+func (tree *figTree) SaveToSigned(path string, key ed25519.PrivateKey) error {
+	if len(key) != ed25519.PrivateKeySize {
+		return fmt.Errorf("invalid ed25519 private key of %d bytes", len(key))
+	}
+	if err := tree.SaveTo(path); err != nil {
+		return err
+	}
+	return SignFile(path, key)
+}
+
+// verify returns the signed contents of the config file at path when Options.TrustedKeys is set
+//
+// The signature is read from path plus SignatureSuffix or else from a last line of data like
+// # figtree-signature: <base64>, which is not part of the signed contents. A config file that is not signed by one
+// of the TrustedKeys is an ErrLoadFailure caused by ErrSignature.
+func (tree *figTree) verify(path string, data []byte) ([]byte, error) {
+	if len(tree.trustedKeys) == 0 {
+		return data, nil
+	}
+	signed, signature, from, err := signatureOf(path, data)
+	if err != nil {
+		return nil, ErrLoadFailure{path, ErrSignature{from, err}}
+	}
+	for _, key := range tree.trustedKeys {
+		if len(key) == ed25519.PublicKeySize && ed25519.Verify(key, signed, signature) {
+			return signed, nil
+		}
+	}
+	return nil, ErrLoadFailure{path, ErrSignature{from, errors.New("not signed by any of the TrustedKeys")}}
+}
+
+// signatureOf returns the contents of data that are signed, the signature and where the signature came from
+func signatureOf(path string, data []byte) ([]byte, []byte, string, error) {
+	detached := path + SignatureSuffix
+	encoded, err := os.ReadFile(detached)
+	switch {
+	case err == nil:
+		signature, err := decodeSignature(string(encoded))
+		return data, signature, detached, err
+	case !os.IsNotExist(err):
+		return nil, nil, detached, err
+	}
+	body := bytes.TrimRight(data, "\r\n")
+	start := bytes.LastIndexByte(body, '\n') + 1
+	if !bytes.HasPrefix(body[start:], []byte(signatureBlock)) {
+		return nil, nil, detached, errors.New("missing, and no signature block is embedded")
+	}
+	signature, err := decodeSignature(strings.TrimPrefix(string(body[start:]), signatureBlock))
+	return data[:start], signature, "block", err
+}
+
+// decodeSignature returns the ed25519 signature encoded as base64
+func decodeSignature(encoded string) ([]byte, error) {
+	signature, err := base64.StdEncoding.DecodeString(strings.TrimSpace(encoded))
+	if err != nil {
+		return nil, err
+	}
+	if len(signature) != ed25519.SignatureSize {
+		return nil, fmt.Errorf("signature of %d bytes is not ed25519", len(signature))
+	}
+	return signature, nil
+}
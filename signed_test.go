@@ -0,0 +1,83 @@
+package figtree
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTree_TrustedKeys(t *testing.T) {
+	os.Args = []string{os.Args[0]}
+	public, private, err := ed25519.GenerateKey(rand.Reader)
+	assert.NoError(t, err)
+	stranger, _, err := ed25519.GenerateKey(rand.Reader)
+	assert.NoError(t, err)
+	dir := t.TempDir()
+	grow := func(keys ...ed25519.PublicKey) Plant {
+		figs := With(Options{Germinate: true, IgnoreEnvironment: true, TrustedKeys: keys})
+		figs.NewString("host", "localhost", "database host")
+		return figs
+	}
+	refused := func(figs Plant, path string) ErrSignature {
+		err := figs.LoadFile(path)
+		var failure ErrLoadFailure
+		var signature ErrSignature
+		assert.True(t, errors.As(err, &failure), path)
+		assert.Equal(t, path, failure.What)
+		assert.True(t, errors.As(err, &signature), path)
+		assert.Equal(t, "localhost", *figs.String("host"), "nothing is parsed from a refused file")
+		return signature
+	}
+
+	config := filepath.Join(dir, "config.json")
+	assert.NoError(t, os.WriteFile(config, []byte(`{"host": "db.internal"}`), 0600))
+	assert.Equal(t, config+SignatureSuffix, refused(grow(public), config).Signature)
+	assert.NoError(t, grow().LoadFile(config), "without TrustedKeys nothing is verified")
+
+	assert.NoError(t, SignFile(config, private))
+	figs := grow(stranger, public)
+	assert.NoError(t, figs.LoadFile(config))
+	assert.Equal(t, "db.internal", *figs.String("host"))
+	refused(grow(stranger), config)
+
+	assert.NoError(t, os.WriteFile(config, []byte(`{"host": "evil.example"}`), 0600))
+	refused(grow(public), config)
+
+	embedded := filepath.Join(dir, "config.yaml")
+	body := []byte("host: db.internal\n")
+	block := signatureBlock + base64.StdEncoding.EncodeToString(ed25519.Sign(private, body)) + "\n"
+	assert.NoError(t, os.WriteFile(embedded, append(body, block...), 0600))
+	figs = grow(public)
+	assert.NoError(t, figs.LoadFile(embedded))
+	assert.Equal(t, "db.internal", *figs.String("host"))
+	assert.NoError(t, os.WriteFile(embedded, append([]byte("host: evil.example\n"), block...), 0600))
+	assert.Equal(t, "block", refused(grow(public), embedded).Signature)
+
+	figs = With(Options{Germinate: true, TrustedKeys: []ed25519.PublicKey{public[:8]}})
+	assert.Len(t, figs.Problems(), 1)
+}
+
+func TestTree_SaveToSigned(t *testing.T) {
+	os.Args = []string{os.Args[0]}
+	public, private, err := ed25519.GenerateKey(rand.Reader)
+	assert.NoError(t, err)
+	path := filepath.Join(t.TempDir(), "config.toml")
+	figs := With(Options{Germinate: true, IgnoreEnvironment: true})
+	figs.NewInt("port", 8080, "listen port")
+	assert.NoError(t, figs.ParseArgs([]string{"-port", "9090"}))
+	assert.Error(t, figs.SaveToSigned(path, private[:8]))
+	assert.NoError(t, figs.SaveToSigned(path, private))
+	_, err = os.Stat(path + SignatureSuffix)
+	assert.NoError(t, err)
+
+	loaded := With(Options{Germinate: true, IgnoreEnvironment: true, TrustedKeys: []ed25519.PublicKey{public}})
+	loaded.NewInt("port", 8080, "listen port")
+	assert.NoError(t, loaded.LoadFile(path))
+	assert.Equal(t, 9090, *loaded.Int("port"))
+}
@@ -1,7 +1,10 @@
 package figtree
 
 import (
+	"context"
+	"crypto/ed25519"
 	"flag"
+	"os"
 	"sync"
 	"sync/atomic"
 	"time"
@@ -20,11 +23,67 @@ type Withables interface {
 	WithValidator(name string, validator func(interface{}) error) Plant
 	// WithValidators binds a figValidatorFunc to a figFruit that returns Plant
 	WithValidators(name string, validators ...func(interface{}) error) Plant
+	// WithEnv replaces the environment variable name of a figFruit with envNames that are looked up in order
+	WithEnv(name string, envNames ...string) Plant
 }
 
 type Savable interface {
 	// SaveTo will store the Tree in a path file
 	SaveTo(path string) error
+	// SaveToSigned is SaveTo that also writes the detached ed25519 signature of the file
+	SaveToSigned(path string, key ed25519.PrivateKey) error
+	// SaveToEncrypted is SaveTo that writes fields and every secret as ENC[...] envelopes
+	SaveToEncrypted(path string, fields ...string) error
+}
+
+type Watchable interface {
+	// Watch polls the config files loaded by Load or LoadFile and hot reloads them until ctx is done
+	Watch(ctx context.Context) error
+	// StopWatching halts the watcher started by Watch or Options.Watch
+	StopWatching()
+}
+
+type Branchable interface {
+	// NewBranch registers a Branch whose figs are stored under name like db.host and returns it
+	NewBranch(name string) Branch
+	// Branch returns the Branch registered by NewBranch or nil
+	Branch(name string) Branch
+}
+
+type Arguable interface {
+	// NewArg registers the required positional argument name parsed into mut
+	NewArg(name string, mut Mutagenesis, usage string) Plant
+	// NewArgs registers name as a List of every remaining positional argument parsed into mut
+	NewArgs(name string, mut Mutagenesis, usage string) Plant
+	// Args returns the positional arguments that followed the flags
+	Args() []string
+}
+
+type Explainable interface {
+	// SourceOf returns the Provenance of the current value of name
+	SourceOf(name string) Provenance
+	// Explain renders every fig with the chain of sources that resolved its value
+	Explain() string
+}
+
+type Commandable interface {
+	// Command registers a subcommand whose run callback receives its child Plant after Parse or Load validates
+	Command(name string, run func(sub Plant)) Plant
+}
+
+type Bindable interface {
+	// Bind registers a fig for each field of the struct ptr points to and keeps the fields in sync with the figTree
+	Bind(ptr interface{}) error
+}
+
+type Definable interface {
+	// Define registers name with its default value, usage, validators, callbacks and rules all at once
+	Define(name string, value interface{}, usage string, validators []FigValidatorFunc, callbacks []Callback, rules []RuleKind) Plant
+}
+
+type Unmarshalable interface {
+	// Unmarshal populates the struct ptr points to using its fig: tags and validates it with its assure: tags
+	Unmarshal(ptr interface{}) error
 }
 
 type Readable interface {
@@ -37,6 +96,8 @@ type Parsable interface {
 	Parse() error
 	// ParseFile can panic but also can throw an error because it will attempt to load either JSON, YAML or INI file passed into it
 	ParseFile(filename string) error
+	// ParseArgs is Parse with an explicit argv like []string{"-port", "9090"} instead of os.Args[1:]
+	ParseArgs(args []string) error
 }
 
 type Mutable interface {
@@ -53,6 +114,10 @@ type Loadable interface {
 	Load() error
 	// LoadFile accepts a path to a JSON, YAML or INI file to set values
 	LoadFile(path string) error
+	// LoadFiles accepts paths to config files that are merged in order, a later file overriding an earlier one
+	LoadFiles(paths ...string) error
+	// LoadWithArgs is Load with an explicit argv like []string{"-port", "9090"} instead of os.Args[1:]
+	LoadWithArgs(args []string) error
 	// Reload will refresh stored values of properties with their new Environment Variable values
 	Reload() error
 }
@@ -98,6 +163,8 @@ type String interface {
 	String(name string) *string
 	// NewString registers a new string flag by name and returns a pointer to the string storing the initial value
 	NewString(name, value, usage string) Plant
+	// NewSecret registers a new string flag by name with RuleSecret so its value is masked everywhere but String
+	NewSecret(name, usage string) Plant
 	// StoreString replaces name with value and can issue a Mutation when receiving on Mutations()
 	StoreString(name, value string) Plant
 }
@@ -146,6 +213,57 @@ type Mappable interface {
 	StoreMap(name string, value map[string]string) Plant
 }
 
+type Fileable interface {
+	// File returns a pointer to the stored file path by -name=/path/to/file
+	File(name string) *string
+	// NewFile registers a new file path flag by name and returns a pointer to the path storing the initial value
+	NewFile(name, path, usage string) Plant
+	// StoreFile replaces name with path and can issue a Mutation when receiving on Mutations()
+	StoreFile(name, path string) Plant
+	// FileContents reads the contents of the file whose path is stored in name
+	FileContents(name string) ([]byte, error)
+	// FileHandler opens the file whose path is stored in name for reading
+	FileHandler(name string) (*os.File, error)
+	// FileWriteContents writes contents into the file whose path is stored in name
+	FileWriteContents(name string, contents []byte) error
+}
+
+type Directable interface {
+	// Directory returns a pointer to the stored directory path by -name=/path/to/dir
+	Directory(name string) *string
+	// NewDirectory registers a new directory path flag by name and returns a pointer to the path storing the initial value
+	NewDirectory(name, path, usage string) Plant
+	// StoreDirectory replaces name with path and can issue a Mutation when receiving on Mutations()
+	StoreDirectory(name, path string) Plant
+	// DirectoryFlushAll recursively removes everything inside the directory whose path is stored in name
+	DirectoryFlushAll(name string) error
+}
+
+type Semaphorable interface {
+	// Semaphore returns the Sema registered by name whose limit follows the value of the fig
+	Semaphore(name string) Sema
+	// NewSemaphore registers a new Sema by name whose limit is assigned like an int with -name=4
+	NewSemaphore(name string, limit int, usage string) Plant
+	// StoreSemaphore resizes the Sema name to limit and can issue a Mutation when receiving on Mutations()
+	StoreSemaphore(name string, limit int) Plant
+}
+
+// Sema is a counting semaphore whose limit is the value of a Semaphore fig and can be resized while held
+type Sema interface {
+	// Acquire blocks until fewer than Limit holders are active and then holds the Sema
+	Acquire()
+	// AcquireContext is Acquire that returns ctx.Err() when ctx is done before the Sema is held
+	AcquireContext(ctx context.Context) error
+	// TryAcquire holds the Sema without blocking and reports whether it did
+	TryAcquire() bool
+	// Release gives back a hold on the Sema
+	Release()
+	// Limit returns the number of holders allowed at once
+	Limit() int
+	// Holders returns the number of active holders
+	Holders() int
+}
+
 type CoreAbilities interface {
 	Withables
 	Savable
@@ -153,6 +271,14 @@ type CoreAbilities interface {
 	Parsable
 	Mutable
 	Loadable
+	Watchable
+	Unmarshalable
+	Bindable
+	Definable
+	Arguable
+	Explainable
+	Commandable
+	Branchable
 	Divine
 }
 
@@ -165,6 +291,9 @@ type CoreMutations interface {
 	Durable
 	Listable
 	Mappable
+	Fileable
+	Directable
+	Semaphorable
 }
 
 type Core interface {
@@ -186,6 +315,13 @@ type Plant interface {
 	CoreMutations
 }
 
+// Branch is a Plant whose figs are stored on its root figTree under its Path like db.host
+type Branch interface {
+	Plant
+	// Path returns the dotted prefix of the Branch like db or db.replica
+	Path() string
+}
+
 // figTree stores figs that are defined by their name and figFruit as well as a mutations channel and tracking bool for Options.Tracking
 type figTree struct {
 	ConfigFilePath string
@@ -205,6 +341,37 @@ type figTree struct {
 	filterTests    bool
 	angel          *atomic.Bool
 	ignoreEnv      bool
+	watch          bool
+	watchInterval  time.Duration
+	watcher        *figWatcher
+	loadedFiles    []string
+	fileStamps     map[string]fileStamp
+	bindings       map[string][]figBinding
+	branches       map[string]*figBranch
+	semaphores     map[string]*figSemaphore
+	observers      map[string][]*figObserver
+	envPrefix      string
+	envKey         EnvKeyFunc
+	env            EnvSource
+	args           []string
+	adopted        map[string]*flag.Flag
+	commands       map[string]*figCommand
+	selected       *figCommand
+	commandArgs    []string
+	parent         *figTree
+	command        string
+	inherited      map[string]bool
+	posix          bool
+	argDefs        []*figArg
+	positional     []string
+	explain        bool
+	precedence     []Source
+	flagged        map[string]interface{}
+	profiles       bool
+	layered        map[string]bool
+	decrypt        Decrypter
+	keyFile        string
+	trustedKeys    []ed25519.PublicKey
 }
 
 // Mutagenesis stores the type as a string like String, Bool, Float, etc to represent a supported Type
@@ -227,6 +394,49 @@ type Options struct {
 
 	// IgnoreEnvironment is a part of free will, it lets us disregard our environment (ENV vars)
 	IgnoreEnvironment bool
+
+	// EnvSource replaces the process environment as the source of ENV vars (defaults to OSEnv)
+	EnvSource EnvSource
+
+	// AdoptCommandLine imports the flags registered on flag.CommandLine as figs and keeps them in sync after each Parse or Load
+	AdoptCommandLine bool
+
+	// POSIX accepts GNU style --name, --name=value, --no-name for a Bool and bundled one letter flags like -vxf
+	POSIX bool
+
+	// Precedence orders the sources of a value from the lowest to the highest, a Source left out is not read (defaults to DefaultPrecedence)
+	Precedence []Source
+
+	// Profiles registers the ConfigProfileFlag and names the overlays layered over each config file, like prod for
+	// config.prod.yaml, unless the flag or its environment variable selects others
+	Profiles []string
+
+	// Explain registers the ConfigExplainFlag that prints Explain() after Parse or Load resolves every fig
+	Explain bool
+
+	// Args replaces os.Args[1:] as the command line arguments of Parse, ParseFile, Load and LoadFile when not nil
+	Args []string
+
+	// Watch polls the config files resolved by Load or LoadFile and hot reloads changes through Store
+	Watch bool
+
+	// WatchInterval sets how often Watch polls the config files (defaults to DefaultWatchInterval)
+	WatchInterval time.Duration
+
+	// EnvPrefix namespaces every environment variable so port is read from MYAPP_PORT instead of PORT
+	EnvPrefix string
+
+	// EnvKey turns a fig name into its environment variable name before EnvPrefix (defaults to DefaultEnvKey)
+	EnvKey EnvKeyFunc
+
+	// Decrypter opens the ENC[...] values of config files and, when it is also an Encrypter, seals SaveToEncrypted
+	Decrypter Decrypter
+
+	// KeyFile holds the base64 AESKey of ENC[...] values when Decrypter is nil (defaults to the EncryptionKeyEnv variable)
+	KeyFile string
+
+	// TrustedKeys refuses every config file without a detached or embedded ed25519 signature by one of these keys
+	TrustedKeys []ed25519.PublicKey
 }
 
 type FigValidatorFunc func(interface{}) error
@@ -248,6 +458,10 @@ type figFruit struct {
 	Mutagenesis Mutagenesis
 	name        string
 	usage       string
+	envNames    []string
+	provenance  []Provenance
+	template    interface{}
+	derived     interface{}
 }
 
 type figFlesh struct {
@@ -267,6 +481,8 @@ type Flesh interface {
 	IsUnitDuration() bool
 	IsList() bool
 	IsMap() bool
+	IsFile() bool
+	IsDirectory() bool
 
 	ToString() string
 	ToInt() int
@@ -277,6 +493,8 @@ type Flesh interface {
 	ToUnitDuration() time.Duration
 	ToList() []string
 	ToMap() map[string]string
+	ToFile() string
+	ToDirectory() string
 }
 
 type Callback struct {
@@ -296,6 +514,7 @@ type Mutation struct {
 	New         interface{}
 	When        time.Time
 	Error       error
+	Provenance  Provenance
 }
 
 var ListSeparator = ","
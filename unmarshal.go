@@ -0,0 +1,455 @@
+package figtree
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// TagFig is the struct tag that names the fig a field is populated from
+const TagFig = "fig"
+
+// TagAssure is the struct tag that lists the | separated validators a field must pass
+const TagAssure = "assure"
+
+// UnmarshalError describes a struct field that could not be populated or validated by Unmarshal
+type UnmarshalError struct {
+	Field string // Field is the dotted Go path to the struct field like Database.Host
+	Key   string // Key is the name of the fig the field is populated from like db.host
+	Token string // Token is the assure: token that failed like hasPrefix=postgres:// or empty for conversions
+	Err   error
+}
+
+func (e UnmarshalError) Error() string {
+	if e.Token != "" {
+		return fmt.Sprintf("unmarshal %s (fig -%s) failed assure:%q: %s", e.Field, e.Key, e.Token, e.Err.Error())
+	}
+	return fmt.Sprintf("unmarshal %s (fig -%s) failed: %s", e.Field, e.Key, e.Err.Error())
+}
+
+func (e UnmarshalError) Unwrap() error {
+	return e.Err
+}
+
+var durationType = reflect.TypeOf(time.Duration(0))
+
+// Unmarshal populates the struct that ptr points to from the figTree and runs its assure: validators
+//
+// Example:
+//
+//	type DatabaseConfig struct {
+//		Host    string        `fig:"host"    assure:"notEmpty|hasPrefix=postgres://"`
+//		Port    int           `fig:"port"    assure:"inRange=1024,65535"`
+//		Timeout time.Duration `fig:"timeout" assure:"min=5s|max=2m"`
+//	}
+//	var cfg DatabaseConfig
+//	err := figs.Unmarshal(&cfg)
+//	var ue figtree.UnmarshalError
+//	if errors.As(err, &ue) {
+//		log.Printf("%s (fig -%s) failed %s", ue.Field, ue.Key, ue.Token)
+//	}
+//
+// Nested structs are populated from dotted fig names, so a field `fig:"db"` of a struct
+// type fills its `fig:"host"` field from the fig named db.host. Fields without a fig tag use
+// their lowercase field name and fields tagged `fig:"-"` are skipped. Every failing field is
+// reported as an UnmarshalError inside the returned error.
+func (tree *figTree) Unmarshal(ptr interface{}) error {
+	return tree.unmarshal(ptr, "")
+}
+
+// unmarshal populates the struct ptr points to from the figs whose names start with keyPrefix
+func (tree *figTree) unmarshal(ptr interface{}, keyPrefix string) error {
+	rv := reflect.ValueOf(ptr)
+	if rv.Kind() != reflect.Pointer || rv.IsNil() || rv.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("unmarshal requires a non-nil pointer to a struct ; got %T", ptr)
+	}
+	tree.mu.RLock()
+	defer tree.mu.RUnlock()
+	return errors.Join(tree.unmarshalStruct(rv.Elem(), "", keyPrefix)...)
+}
+
+// unmarshalStruct populates each exported field of rv and returns an UnmarshalError for each failure
+func (tree *figTree) unmarshalStruct(rv reflect.Value, fieldPrefix, keyPrefix string) []error {
+	var errs []error
+	rt := rv.Type()
+	for i := 0; i < rt.NumField(); i++ {
+		sf := rt.Field(i)
+		if !sf.IsExported() {
+			continue
+		}
+		key, ok := figKeyOf(sf)
+		if !ok {
+			continue
+		}
+		field := fieldPrefix + sf.Name
+		key = keyPrefix + key
+		fv := rv.Field(i)
+		if sf.Type.Kind() == reflect.Pointer && sf.Type.Elem().Kind() == reflect.Struct {
+			if fv.IsNil() {
+				fv.Set(reflect.New(sf.Type.Elem()))
+			}
+			fv = fv.Elem()
+		}
+		if fv.Kind() == reflect.Struct && fv.Type() != durationType {
+			errs = append(errs, tree.unmarshalStruct(fv, field+".", key+".")...)
+			continue
+		}
+		mut := mutagenesisOfType(sf.Type)
+		if mut == "" {
+			errs = append(errs, UnmarshalError{field, key, "", fmt.Errorf("unsupported field type %s", sf.Type)})
+			continue
+		}
+		if value, err := tree.from(key); err == nil && value != nil {
+			if err := assignField(fv, mut, value.Value); err != nil {
+				errs = append(errs, UnmarshalError{field, key, "", err})
+				continue
+			}
+		}
+		errs = append(errs, assureField(fv, mut, field, key, sf.Tag.Get(TagAssure))...)
+	}
+	return errs
+}
+
+// figKeyOf returns the fig name of a struct field from its fig: tag or its lowercase name
+func figKeyOf(sf reflect.StructField) (string, bool) {
+	tag, has := sf.Tag.Lookup(TagFig)
+	if has && tag == "-" {
+		return "", false
+	}
+	tag = strings.TrimSpace(strings.Split(tag, ",")[0])
+	if tag == "" {
+		tag = sf.Name
+	}
+	return strings.ToLower(tag), true
+}
+
+// mutagenesisOfType returns the Mutagenesis a struct field of type t is converted through
+func mutagenesisOfType(t reflect.Type) Mutagenesis {
+	if t == durationType {
+		return tDuration
+	}
+	switch t.Kind() {
+	case reflect.String:
+		return tString
+	case reflect.Bool:
+		return tBool
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32:
+		return tInt
+	case reflect.Int64, reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return tInt64
+	case reflect.Float32, reflect.Float64:
+		return tFloat64
+	case reflect.Slice:
+		if t.Elem().Kind() == reflect.String {
+			return tList
+		}
+	case reflect.Map:
+		if t.Key().Kind() == reflect.String && t.Elem().Kind() == reflect.String {
+			return tMap
+		}
+	}
+	return ""
+}
+
+// assignField converts raw through the to* helpers of mut and sets it on fv
+func assignField(fv reflect.Value, mut Mutagenesis, raw interface{}) error {
+	converted, err := toMutagenesis(mut, raw)
+	if err != nil {
+		return err
+	}
+	switch fv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		var i int64
+		switch c := converted.(type) {
+		case int:
+			i = int64(c)
+		case int64:
+			i = c
+		case time.Duration:
+			i = int64(c)
+		}
+		if fv.OverflowInt(i) {
+			return fmt.Errorf("%d overflows %s", i, fv.Type())
+		}
+		fv.SetInt(i)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		i := converted.(int64)
+		if i < 0 || fv.OverflowUint(uint64(i)) {
+			return fmt.Errorf("%d overflows %s", i, fv.Type())
+		}
+		fv.SetUint(uint64(i))
+	case reflect.Float32, reflect.Float64:
+		f := converted.(float64)
+		if fv.OverflowFloat(f) {
+			return fmt.Errorf("%v overflows %s", f, fv.Type())
+		}
+		fv.SetFloat(f)
+	case reflect.Slice:
+		list := converted.([]string)
+		slice := reflect.MakeSlice(fv.Type(), len(list), len(list))
+		for i, item := range list {
+			slice.Index(i).Set(reflect.ValueOf(item).Convert(fv.Type().Elem())) // each item so []myString works too
+		}
+		fv.Set(slice)
+	case reflect.Map:
+		m := reflect.MakeMapWithSize(fv.Type(), len(converted.(map[string]string)))
+		for k, v := range converted.(map[string]string) {
+			m.SetMapIndex(reflect.ValueOf(k).Convert(fv.Type().Key()), reflect.ValueOf(v).Convert(fv.Type().Elem()))
+		}
+		fv.Set(m)
+	default:
+		fv.Set(reflect.ValueOf(converted).Convert(fv.Type()))
+	}
+	return nil
+}
+
+// canonicalOf returns the value of fv as the Go type the Assure validators of mut expect
+func canonicalOf(fv reflect.Value, mut Mutagenesis) interface{} {
+	switch mut {
+	case tInt:
+		return int(fv.Int())
+	case tInt64:
+		if fv.CanUint() {
+			return int64(fv.Uint())
+		}
+		return fv.Int()
+	case tFloat64:
+		return fv.Float()
+	case tDuration:
+		return time.Duration(fv.Int())
+	case tString:
+		return fv.String()
+	case tBool:
+		return fv.Bool()
+	case tList:
+		list := make([]string, fv.Len())
+		for i := range list {
+			list[i] = fv.Index(i).String() // each item so []myString works too
+		}
+		return list
+	case tMap:
+		m := make(map[string]string, fv.Len())
+		iter := fv.MapRange()
+		for iter.Next() {
+			m[iter.Key().String()] = iter.Value().String()
+		}
+		return m
+	default:
+		return fv.Interface()
+	}
+}
+
+// assureField runs each | separated token of tag against fv and returns an UnmarshalError for each failure
+func assureField(fv reflect.Value, mut Mutagenesis, field, key, tag string) []error {
+	if strings.TrimSpace(tag) == "" {
+		return nil
+	}
+	var errs []error
+	value := canonicalOf(fv, mut)
+	for _, token := range strings.Split(tag, "|") {
+		token = strings.TrimSpace(token)
+		if token == "" {
+			continue
+		}
+		validator, err := AssureFromToken(mut, token)
+		if err != nil {
+			errs = append(errs, UnmarshalError{field, key, token, err})
+			continue
+		}
+		if err := validator(value); err != nil {
+			errs = append(errs, UnmarshalError{field, key, token, err})
+		}
+	}
+	return errs
+}
+
+// AssureFromToken returns the Assure validator that an assure: struct tag token names for a Mutagenesis
+//
+// Example:
+//
+//	validator, err := figtree.AssureFromToken(figtree.MutagenesisOf(""), "hasPrefix=postgres://")
+//	// validator is figtree.AssureStringHasPrefix("postgres://")
+func AssureFromToken(mut Mutagenesis, token string) (FigValidatorFunc, error) {
+	name, arg, _ := strings.Cut(token, "=")
+	name = strings.ToLower(strings.TrimSpace(name))
+	tokens, ok := assureTokens[mut]
+	if !ok {
+		return nil, fmt.Errorf("no assure tokens for %s", mut)
+	}
+	build, ok := tokens[name]
+	if !ok {
+		return nil, fmt.Errorf("unknown assure token %q for %s", name, mut)
+	}
+	return build(arg)
+}
+
+// assureTokens maps each Mutagenesis to the lowercase assure: token names it accepts
+var assureTokens = map[Mutagenesis]map[string]func(arg string) (FigValidatorFunc, error){
+	tString: {
+		"notempty":      noArg(AssureStringNotEmpty),
+		"contains":      stringArg(AssureStringContains),
+		"notcontains":   stringArg(AssureStringNotContains),
+		"substring":     stringArg(AssureStringSubstring),
+		"hasprefix":     stringArg(AssureStringHasPrefix),
+		"hassuffix":     stringArg(AssureStringHasSuffix),
+		"noprefix":      stringArg(AssureStringNoPrefix),
+		"nosuffix":      stringArg(AssureStringNoSuffix),
+		"hasprefixes":   listArg(AssureStringHasPrefixes),
+		"hassuffixes":   listArg(AssureStringHasSuffixes),
+		"noprefixes":    listArg(AssureStringNoPrefixes),
+		"nosuffixes":    listArg(AssureStringNoSuffixes),
+		"length":        intArg(AssureStringLength),
+		"notlength":     intArg(AssureStringNotLength),
+		"lengthgreater": intArg(AssureStringLengthGreaterThan),
+		"lengthless":    intArg(AssureStringLengthLessThan),
+		"fileexists":    noArg(AssureFileExists),
+		"direxists":     noArg(AssureDirExists),
+	},
+	tBool: {
+		"true":  noArg(AssureBoolTrue),
+		"false": noArg(AssureBoolFalse),
+	},
+	tInt: {
+		"positive":    noArg(AssureIntPositive),
+		"negative":    noArg(AssureIntNegative),
+		"greaterthan": intArg(AssureIntGreaterThan),
+		"lessthan":    intArg(AssureIntLessThan),
+		"inrange": func(arg string) (FigValidatorFunc, error) {
+			lo, hi, err := rangeArg(arg, strconv.Atoi)
+			if err != nil {
+				return nil, err
+			}
+			return AssureIntInRange(lo, hi), nil
+		},
+	},
+	tInt64: {
+		"positive": noArg(AssureInt64Positive),
+		"greaterthan": func(arg string) (FigValidatorFunc, error) {
+			i, err := strconv.ParseInt(arg, 10, 64)
+			if err != nil {
+				return nil, err
+			}
+			return AssureInt64GreaterThan(i), nil
+		},
+		"lessthan": func(arg string) (FigValidatorFunc, error) {
+			i, err := strconv.ParseInt(arg, 10, 64)
+			if err != nil {
+				return nil, err
+			}
+			return AssureInt64LessThan(i), nil
+		},
+		"inrange": func(arg string) (FigValidatorFunc, error) {
+			lo, hi, err := rangeArg(arg, func(s string) (int64, error) { return strconv.ParseInt(s, 10, 64) })
+			if err != nil {
+				return nil, err
+			}
+			return AssureInt64InRange(lo, hi), nil
+		},
+	},
+	tFloat64: {
+		"positive":    noArg(AssureFloat64Positive),
+		"notnan":      noArg(AssureFloat64NotNaN),
+		"greaterthan": floatArg(AssureFloat64GreaterThan),
+		"lessthan":    floatArg(AssureFloat64LessThan),
+		"inrange": func(arg string) (FigValidatorFunc, error) {
+			lo, hi, err := rangeArg(arg, func(s string) (float64, error) { return strconv.ParseFloat(s, 64) })
+			if err != nil {
+				return nil, err
+			}
+			return AssureFloat64InRange(lo, hi), nil
+		},
+	},
+	tDuration: {
+		"positive":    noArg(AssureDurationPositive),
+		"min":         durationArg(AssureDurationMin),
+		"max":         durationArg(AssureDurationMax),
+		"greaterthan": durationArg(AssureDurationGreaterThan),
+		"lessthan":    durationArg(AssureDurationLessThan),
+	},
+	tList: {
+		"notempty":    noArg(AssureListNotEmpty),
+		"minlength":   intArg(AssureListMinLength),
+		"length":      intArg(AssureListLength),
+		"contains":    stringArg(AssureListContains),
+		"notcontains": stringArg(AssureListNotContains),
+		"containskey": stringArg(AssureListContainsKey),
+	},
+	tMap: {
+		"notempty":  noArg(AssureMapNotEmpty),
+		"haskey":    stringArg(AssureMapHasKey),
+		"hasnokey":  stringArg(AssureMapHasNoKey),
+		"haskeys":   listArg(AssureMapHasKeys),
+		"length":    intArg(AssureMapLength),
+		"notlength": intArg(AssureMapNotLength),
+		"valuematches": func(arg string) (FigValidatorFunc, error) {
+			k, v, ok := strings.Cut(arg, MapKeySeparator)
+			if !ok {
+				return nil, fmt.Errorf("valueMatches requires key%svalue ; got %q", MapKeySeparator, arg)
+			}
+			return AssureMapValueMatches(k, v), nil
+		},
+	},
+}
+
+func noArg(validator FigValidatorFunc) func(string) (FigValidatorFunc, error) {
+	return func(string) (FigValidatorFunc, error) {
+		return validator, nil
+	}
+}
+
+func stringArg(build func(string) FigValidatorFunc) func(string) (FigValidatorFunc, error) {
+	return func(arg string) (FigValidatorFunc, error) {
+		return build(arg), nil
+	}
+}
+
+func listArg(build func([]string) FigValidatorFunc) func(string) (FigValidatorFunc, error) {
+	return func(arg string) (FigValidatorFunc, error) {
+		return build(strings.Split(arg, ListSeparator)), nil
+	}
+}
+
+func intArg(build func(int) FigValidatorFunc) func(string) (FigValidatorFunc, error) {
+	return func(arg string) (FigValidatorFunc, error) {
+		i, err := strconv.Atoi(arg)
+		if err != nil {
+			return nil, err
+		}
+		return build(i), nil
+	}
+}
+
+func floatArg(build func(float64) FigValidatorFunc) func(string) (FigValidatorFunc, error) {
+	return func(arg string) (FigValidatorFunc, error) {
+		f, err := strconv.ParseFloat(arg, 64)
+		if err != nil {
+			return nil, err
+		}
+		return build(f), nil
+	}
+}
+
+func durationArg(build func(time.Duration) FigValidatorFunc) func(string) (FigValidatorFunc, error) {
+	return func(arg string) (FigValidatorFunc, error) {
+		d, err := toDuration(arg)
+		if err != nil {
+			return nil, err
+		}
+		return build(d), nil
+	}
+}
+
+func rangeArg[T any](arg string, parse func(string) (T, error)) (lo, hi T, err error) {
+	a, b, ok := strings.Cut(arg, ",")
+	if !ok {
+		return lo, hi, fmt.Errorf("inRange requires min,max ; got %q", arg)
+	}
+	if lo, err = parse(strings.TrimSpace(a)); err != nil {
+		return lo, hi, err
+	}
+	hi, err = parse(strings.TrimSpace(b))
+	return lo, hi, err
+}
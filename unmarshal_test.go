@@ -0,0 +1,139 @@
+package figtree
+
+import (
+	"errors"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type unmarshalDatabase struct {
+	Host    string        `fig:"host"    assure:"notEmpty|hasPrefix=postgres://"`
+	Port    int           `fig:"port"    assure:"inRange=1024,65535"`
+	Timeout time.Duration `fig:"timeout" assure:"min=5s|max=2m"`
+}
+
+type unmarshalConfig struct {
+	Name     string            `fig:"name" assure:"notEmpty"`
+	Workers  uint8             `fig:"workers"`
+	Ratio    float32           `fig:"ratio" assure:"inRange=0,1"`
+	Debug    bool              `fig:"debug"`
+	Tags     []string          `fig:"tags" assure:"contains=api"`
+	Labels   map[string]string `fig:"labels" assure:"hasKeys=env"`
+	Database unmarshalDatabase `fig:"db"`
+	Skipped  string            `fig:"-"`
+	internal string
+}
+
+func TestTree_Unmarshal(t *testing.T) {
+	os.Args = []string{os.Args[0]}
+	figs := With(Options{Germinate: true, IgnoreEnvironment: true})
+	figs.NewString("name", "figtree", "name")
+	figs.NewInt("workers", 4, "workers")
+	figs.NewFloat64("ratio", 0.5, "ratio")
+	figs.NewBool("debug", true, "debug")
+	figs.NewList("tags", []string{"api", "web"}, "tags")
+	figs.NewMap("labels", map[string]string{"env": "prod"}, "labels")
+	figs.NewString("db.host", "postgres://localhost", "db host")
+	figs.NewInt("db.port", 5432, "db port")
+	figs.NewDuration("db.timeout", 30*time.Second, "db timeout")
+	figs.NewString("skipped", "ignored", "skipped")
+	assert.NoError(t, figs.Parse())
+
+	var cfg unmarshalConfig
+	assert.NoError(t, figs.Unmarshal(&cfg))
+	assert.Equal(t, "figtree", cfg.Name)
+	assert.Equal(t, uint8(4), cfg.Workers)
+	assert.Equal(t, float32(0.5), cfg.Ratio)
+	assert.True(t, cfg.Debug)
+	assert.Equal(t, []string{"api", "web"}, cfg.Tags)
+	assert.Equal(t, map[string]string{"env": "prod"}, cfg.Labels)
+	assert.Equal(t, "postgres://localhost", cfg.Database.Host)
+	assert.Equal(t, 5432, cfg.Database.Port)
+	assert.Equal(t, 30*time.Second, cfg.Database.Timeout)
+	assert.Empty(t, cfg.Skipped)
+	assert.Empty(t, cfg.internal)
+
+	assert.Error(t, figs.Unmarshal(cfg))
+	assert.Error(t, figs.Unmarshal((*unmarshalConfig)(nil)))
+}
+
+func TestTree_Unmarshal_Errors(t *testing.T) {
+	os.Args = []string{os.Args[0]}
+	figs := With(Options{Germinate: true, IgnoreEnvironment: true})
+	figs.NewString("db.host", "mysql://localhost", "db host")
+	figs.NewInt("db.port", 80, "db port")
+	figs.NewString("db.timeout", "1s", "db timeout")
+	assert.NoError(t, figs.Parse())
+
+	var cfg unmarshalDatabase
+	err := figs.Unmarshal(&struct {
+		DB *unmarshalDatabase `fig:"db"`
+	}{DB: &cfg})
+	assert.Error(t, err)
+	assert.Equal(t, "mysql://localhost", cfg.Host)
+	assert.Equal(t, 80, cfg.Port)
+
+	var wrapped struct {
+		Database unmarshalDatabase `fig:"db"`
+		Port     string            `fig:"db.port" assure:"bogus"`
+	}
+	err = figs.Unmarshal(&wrapped)
+	assert.Error(t, err)
+
+	var ue UnmarshalError
+	assert.True(t, errors.As(err, &ue))
+	assert.Equal(t, "Database.Host", ue.Field)
+	assert.Equal(t, "db.host", ue.Key)
+	assert.Equal(t, "hasPrefix=postgres://", ue.Token)
+
+	joined, ok := err.(interface{ Unwrap() []error })
+	assert.True(t, ok)
+	var tokens []string
+	for _, e := range joined.Unwrap() {
+		if errors.As(e, &ue) {
+			tokens = append(tokens, ue.Key+" "+ue.Token)
+		}
+	}
+	assert.Equal(t, []string{
+		"db.host hasPrefix=postgres://",
+		"db.port inRange=1024,65535",
+		"db.timeout min=5s",
+		"db.port bogus",
+	}, tokens)
+}
+
+type label string
+
+func TestTree_Unmarshal_NamedElements(t *testing.T) {
+	os.Args = []string{os.Args[0]}
+	figs := With(Options{Germinate: true, IgnoreEnvironment: true})
+	figs.NewList("tags", []string{}, "tags")
+	figs.NewMap("labels", map[string]string{}, "labels")
+	assert.NoError(t, figs.ParseArgs([]string{"-tags", "web,api", "-labels", "env=prod"}))
+	var cfg struct {
+		Tags   []label         `fig:"tags"`
+		Labels map[label]label `fig:"labels"`
+	}
+	assert.NotPanics(t, func() { assert.NoError(t, figs.Unmarshal(&cfg)) })
+	assert.ElementsMatch(t, []label{"web", "api"}, cfg.Tags)
+	assert.Equal(t, map[label]label{"env": "prod"}, cfg.Labels)
+}
+
+func TestAssureFromToken(t *testing.T) {
+	validator, err := AssureFromToken(tString, "hasPrefix=postgres://")
+	assert.NoError(t, err)
+	assert.NoError(t, validator("postgres://localhost"))
+	assert.Error(t, validator("mysql://localhost"))
+
+	validator, err = AssureFromToken(tMap, "valueMatches=env=prod")
+	assert.NoError(t, err)
+	assert.NoError(t, validator(map[string]string{"env": "prod"}))
+
+	_, err = AssureFromToken(tInt, "inRange=1")
+	assert.Error(t, err)
+	_, err = AssureFromToken(tBool, "notEmpty")
+	assert.Error(t, err)
+}
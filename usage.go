@@ -15,7 +15,16 @@ func (tree *figTree) Usage() {
 	fmt.Println(tree.UsageString())
 }
 
+// UsageString renders the figs of the figTree, the figs a command inherits, its positional arguments and the commands it can run
 func (tree *figTree) UsageString() string {
+	if tree.parent != nil {
+		tree.inherit()
+	}
+	return tree.usageString("")
+}
+
+// usageString renders the figs whose names start with the branch path prefix grouped by their Branch
+func (tree *figTree) usageString(prefix string) string {
 	termWidth := 80
 	if term.IsTerminal(int(os.Stdout.Fd())) {
 		if width, _, err := term.GetSize(int(os.Stdout.Fd())); err == nil {
@@ -30,29 +39,47 @@ func (tree *figTree) UsageString() string {
 		aliases      []string
 		defValue     string
 		usage        string
+		env          string
 		mutagenesis  Mutagenesis
 		isAlias      bool   // Mark if this is an alias entry itself
 		originalName string // For aliases, store the original flag name
 	}
 	allFlagData := make(map[string]*flagInfo) // Use map to deduplicate and process by main flag name
+	groups := make(map[string][]string)       // Branch path to the main flag names it owns
+	globals := make([]string, 0)              // Figs a command inherits from its parent
+	var commands []string
 
 	tree.mu.RLock() // Lock for accessing tree.figs and tree.aliases
 
 	// Populate with main flags
 	for name, fruit := range tree.figs {
+		if prefix != "" && !strings.HasPrefix(name, prefix+".") {
+			continue
+		}
 		f := tree.flagSet.Lookup(name) // Get the flag.Flag object
 		if f == nil {
 			continue // Should not happen if figs map is consistent with flagSet
 		}
 
+		defValue := f.DefValue
+		if tree.masked(name, strings.Trim(defValue, `"`)) == SecretMask {
+			defValue = SecretMask // a secret only shows that it has a default
+		}
 		info := &flagInfo{
 			name:        f.Name,
-			defValue:    f.DefValue,
+			defValue:    defValue,
 			usage:       f.Usage,
+			env:         tree.envUsage(name),
 			mutagenesis: fruit.Mutagenesis, // Get mutagenesis from figFruit
 			isAlias:     false,
 		}
 		allFlagData[name] = info
+		if tree.inherited[name] {
+			globals = append(globals, name)
+			continue
+		}
+		owner := tree.branchOf(name)
+		groups[owner] = append(groups[owner], name)
 	}
 
 	// Add aliases to their primary flags
@@ -62,6 +89,16 @@ func (tree *figTree) UsageString() string {
 		}
 	}
 
+	var args []*figArg
+	argsForm := ""
+	if prefix == "" {
+		commands = tree.commandNames()
+		args = append(args, tree.argDefs...)
+		argsForm = tree.argsUsage()
+	}
+	command := tree.command
+	posix := tree.posix
+
 	tree.mu.RUnlock() // Release lock
 
 	// Second pass: Re-evaluate maxFlagLen based on combined flag names
@@ -81,6 +118,9 @@ func (tree *figTree) UsageString() string {
 
 			flagStr = fmt.Sprintf("%s|-%s", strings.Join(sortedAliases, "|-"), info.name)
 		}
+		if posix {
+			flagStr = posixFlagNames(info.aliases, info.name, info.mutagenesis == tBool)
+		}
 
 		displayValue := info.defValue
 		if displayValue == `""` || displayValue == "[]" || displayValue == "{}" {
@@ -96,15 +136,44 @@ func (tree *figTree) UsageString() string {
 	}
 
 	var sb strings.Builder
-	_, _ = fmt.Fprintf(&sb, "Usage of %s (powered by figtree %s):\n", filepath.Base(os.Args[0]), Version())
+	program := filepath.Base(os.Args[0])
+	if command != "" {
+		program += " " + command
+	}
+	if len(commands) > 0 {
+		program += " [command]"
+	}
+	if argsForm != "" {
+		program += " " + argsForm
+	}
+	_, _ = fmt.Fprintf(&sb, "Usage of %s (powered by figtree %s):\n", program, Version())
+
+	sortedGroups := make([]string, 0, len(groups))
+	for owner := range groups {
+		sortedGroups = append(sortedGroups, owner)
+	}
+	sort.Strings(sortedGroups)
 
 	sortedFlagNames := make([]string, 0, len(allFlagData))
-	for name := range allFlagData {
-		sortedFlagNames = append(sortedFlagNames, name)
+	firstOf := make(map[string]string, len(groups)) // First flag name of each Branch to print its heading
+	for _, owner := range sortedGroups {
+		names := groups[owner]
+		sort.Strings(names)
+		if owner != "" && owner != prefix {
+			firstOf[names[0]] = owner
+		}
+		sortedFlagNames = append(sortedFlagNames, names...)
+	}
+	if len(globals) > 0 {
+		sort.Strings(globals)
+		firstOf[globals[0]] = "global flags"
+		sortedFlagNames = append(sortedFlagNames, globals...)
 	}
-	sort.Strings(sortedFlagNames)
 
 	for _, name := range sortedFlagNames {
+		if owner, ok := firstOf[name]; ok {
+			_, _ = fmt.Fprintf(&sb, "\n %s:\n", owner)
+		}
 		info := allFlagData[name]
 
 		flagStr := info.name
@@ -114,6 +183,9 @@ func (tree *figTree) UsageString() string {
 			sort.Strings(sortedAliases)
 			flagStr = fmt.Sprintf("%s|-%s", strings.Join(sortedAliases, "|-"), info.name)
 		}
+		if posix {
+			flagStr = posixFlagNames(info.aliases, info.name, info.mutagenesis == tBool)
+		}
 
 		displayValue := info.defValue
 		if displayValue == `""` || displayValue == "[]" || displayValue == "{}" {
@@ -127,6 +199,9 @@ func (tree *figTree) UsageString() string {
 
 		// The f.Usage is the usage string of the main flag.
 		line := fmt.Sprintf("   -%-*s   %-8s   %s", maxFlagLen, flagStr, typeField, info.usage)
+		if info.env != "" {
+			line = fmt.Sprintf("%s (%s)", line, info.env)
+		}
 
 		// Wrap the usage text if it exceeds terminal width
 		lines := wrapText(line, termWidth, maxFlagLen+8+3+3) // 8 for type field, 3 spaces each side
@@ -141,9 +216,50 @@ func (tree *figTree) UsageString() string {
 		}
 	}
 
+	if len(args) > 0 {
+		_, _ = fmt.Fprintf(&sb, "\nArguments:\n")
+		for _, def := range args {
+			typeField := fmt.Sprintf("[%s]", def.mutagenesis)
+			if def.variadic {
+				typeField = fmt.Sprintf("[...%s]", def.mutagenesis)
+			}
+			_, _ = fmt.Fprintf(&sb, "   %s   %s   %s\n", argForm(def), typeField, def.usage)
+		}
+	}
+
+	if len(commands) > 0 {
+		_, _ = fmt.Fprintf(&sb, "\nCommands:\n")
+		for _, name := range commands {
+			_, _ = fmt.Fprintf(&sb, "   %s\n", name)
+		}
+	}
+
 	return sb.String()
 }
 
+// posixFlagNames lists the one letter names as -x and the longer names as --name or --[no-]name for a Bool without the leading dash
+func posixFlagNames(aliases []string, name string, isBool bool) string {
+	names := append([]string{name}, aliases...)
+	sort.Slice(names, func(i, j int) bool {
+		if (len(names[i]) == 1) != (len(names[j]) == 1) {
+			return len(names[i]) == 1
+		}
+		return names[i] < names[j]
+	})
+	forms := make([]string, 0, len(names))
+	for _, n := range names {
+		switch {
+		case len(n) == 1:
+			forms = append(forms, "-"+n)
+		case isBool:
+			forms = append(forms, "--[no-]"+n)
+		default:
+			forms = append(forms, "--"+n)
+		}
+	}
+	return strings.TrimPrefix(strings.Join(forms, ", "), "-")
+}
+
 // wrapText wraps a line of text to fit within the terminal width, indenting wrapped lines
 func wrapText(line string, termWidth int, indentLen int) []string {
 	words := strings.Fields(line)
@@ -3,7 +3,13 @@ package figtree
 import (
 	"fmt"
 	"log"
+	"os/user"
+	"strconv"
 	"time"
+
+	check "github.com/andreimerlescu/checkfs"
+	"github.com/andreimerlescu/checkfs/directory"
+	"github.com/andreimerlescu/checkfs/file"
 )
 
 // WithValidator adds a validator to an int flag
@@ -49,9 +55,16 @@ func (tree *figTree) WithValidators(name string, validators ...func(interface{})
 }
 
 // validateAll looks at figFruit FigValidatorFunc and returns the error if it fails otherwise it calls figTree.runCallbacks()
+//
+// The values of figs with RuleSecret are masked in the returned error.
 func (tree *figTree) validateAll() error {
 	tree.mu.RLock()
 	defer tree.mu.RUnlock()
+	return tree.redact(tree.validateFigs())
+}
+
+// validateFigs requires the figTree.mu to be locked and is validateAll without masking the secrets
+func (tree *figTree) validateFigs() error {
 	err := tree.runCallbacks(CallbackBeforeVerify)
 	if err != nil {
 		return err
@@ -123,7 +136,7 @@ func (tree *figTree) validateAll() error {
 					log.Printf("val is nil for %s", name)
 				}
 				if err := validator(val); err != nil {
-					return fmt.Errorf("validation failed for %s: %v", name, err)
+					return fmt.Errorf("validation failed for %s: %v", name, tree.maskedError(name, err))
 				}
 			}
 		}
@@ -147,8 +160,80 @@ func makeStringValidator(check func(string) bool, errFormat string) FigValidator
 		}
 		s := v.ToString()
 		if !check(s) {
-			return fmt.Errorf(errFormat, s)
+			return errRejected{errFormat, s}
 		}
 		return nil
 	}
 }
+
+// errRejected is the error of a string validator, kept apart from its value so a secret can be masked by name
+type errRejected struct {
+	format string
+	value  string
+}
+
+func (e errRejected) Error() string {
+	return fmt.Sprintf(e.format, e.value)
+}
+
+// makeFileValidator creates a validator that runs checkfs file.Options against a path.
+func makeFileValidator(opts file.Options) FigValidatorFunc {
+	return func(value interface{}) error {
+		v := figFlesh{value, nil}
+		if !v.IsString() {
+			return ErrInvalidType{tFile, value}
+		}
+		return check.File(v.ToString(), opts)
+	}
+}
+
+// makeDirectoryValidator creates a validator that runs checkfs directory.Options against a path.
+func makeDirectoryValidator(opts directory.Options) FigValidatorFunc {
+	return func(value interface{}) error {
+		v := figFlesh{value, nil}
+		if !v.IsString() {
+			return ErrInvalidType{tDirectory, value}
+		}
+		return check.Directory(v.ToString(), opts)
+	}
+}
+
+// lookupOwner returns the uid of owner when owner is a user name rather than a numeric uid.
+func lookupOwner(owner string) string {
+	if _, err := strconv.Atoi(owner); err == nil {
+		return owner
+	}
+	if u, err := user.Lookup(owner); err == nil {
+		return u.Uid
+	}
+	return owner
+}
+
+// lookupGroup returns the gid of group when group is a group name rather than a numeric gid.
+func lookupGroup(group string) string {
+	if _, err := strconv.Atoi(group); err == nil {
+		return group
+	}
+	if g, err := user.LookupGroup(group); err == nil {
+		return g.Gid
+	}
+	return group
+}
+
+// semaphoreLimitOf returns the limit of a Semaphore from its int value or its Sema
+func semaphoreLimitOf(value interface{}) (int, bool) {
+	switch v := value.(type) {
+	case Sema:
+		return v.Limit(), true
+	case int:
+		return v, true
+	case *int:
+		if v == nil {
+			return 0, false
+		}
+		return *v, true
+	default:
+		limit, err := toInt(v)
+		return limit, err == nil
+	}
+}
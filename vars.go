@@ -2,8 +2,10 @@ package figtree
 
 import (
 	"embed"
+	"os"
 	"path/filepath"
 	"strings"
+	"time"
 )
 
 //go:embed VERSION
@@ -26,6 +28,7 @@ const (
 	DefaultYAMLFile string = "config.yml"  // Default filename for a YAML configuration file
 	DefaultJSONFile string = "config.json" // Default filename for a JSON configuration file
 	DefaultINIFile  string = "config.ini"  // Default filename for a INI configuration file
+	DefaultTOMLFile string = "config.toml" // Default filename for a TOML configuration file
 
 	tString       Mutagenesis = "String"
 	tBool         Mutagenesis = "Bool"
@@ -36,6 +39,9 @@ const (
 	tUnitDuration Mutagenesis = "UnitDuration"
 	tList         Mutagenesis = "List"
 	tMap          Mutagenesis = "Map"
+	tFile         Mutagenesis = "File"
+	tDirectory    Mutagenesis = "Directory"
+	tSemaphore    Mutagenesis = "Semaphore"
 
 	CallbackAfterChange  CallbackWhen = "CallbackAfterChange"
 	CallbackAfterRead    CallbackWhen = "CallbackAfterRead"
@@ -46,10 +52,41 @@ const (
 )
 
 // Mutageneses is the plural form of Mutagenesis and this is a slice of Mutagenesis
-var Mutageneses = []Mutagenesis{tString, tBool, tInt, tInt64, tFloat64, tDuration, tUnitDuration, tList, tMap}
+var Mutageneses = []Mutagenesis{tString, tBool, tInt, tInt64, tFloat64, tDuration, tUnitDuration, tList, tMap, tFile, tDirectory, tSemaphore}
 
-// EnvironmentKey stores the preferred ENV that contains the path to your configuration file (.ini, .json or .yaml)
+// EnvironmentKey stores the preferred ENV that contains the path to your configuration file (.ini, .json, .toml or .yaml)
 var EnvironmentKey string = "CONFIG_FILE"
 
+// ConfigExplainFlag is the Bool fig registered by Options.Explain that prints Explain() after Parse or Load
+var ConfigExplainFlag string = "config-explain"
+
+// ConfigProfileFlag is the String fig registered by Options.Profiles that selects the config overlays to layer
+var ConfigProfileFlag string = "profile"
+
+// DefaultPrecedence lets a command line flag beat an environment variable, which beats a config file, which beats the default
+var DefaultPrecedence = []Source{SourceDefault, SourceFile, SourceEnv, SourceFlag}
+
 // ConfigFilePath stores the path to the configuration file of choice
 var ConfigFilePath string = filepath.Join(".", DefaultYAMLFile)
+
+// SignatureSuffix is appended to the path of a config file to name its detached signature
+var SignatureSuffix = ".sig"
+
+// EncryptionKeyEnv names the environment variable holding the base64 AESKey of ENC[...] values when neither
+// Options.Decrypter nor Options.KeyFile is set
+var EncryptionKeyEnv = "FIGTREE_KEY"
+
+// SecretMask replaces the value of a fig with RuleSecret wherever the figTree renders it
+var SecretMask = "******"
+
+// SecretFileSuffix is appended to the environment variable of a fig with RuleSecretFiles to name its secret file
+var SecretFileSuffix = "_FILE"
+
+// SecretFileMaxSize is the largest secret file in bytes that RuleSecretFiles reads
+var SecretFileMaxSize int64 = 64 << 10
+
+// SecretFileMode is the most permissive mode a secret file read by RuleSecretFiles may have
+var SecretFileMode os.FileMode = 0644
+
+// DefaultWatchInterval is how often Options.Watch polls config files when Options.WatchInterval is unset
+var DefaultWatchInterval = time.Second
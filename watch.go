@@ -0,0 +1,375 @@
+package figtree
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"reflect"
+	"slices"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// figWatcher polls the files that were loaded into the figTree and reports changes
+type figWatcher struct {
+	cancel   context.CancelFunc
+	done     chan struct{}
+	files    []string
+	interval time.Duration
+}
+
+// fileStamp is what the figWatcher compares between polls to detect a change
+type fileStamp struct {
+	modTime time.Time
+	size    int64
+}
+
+// figSnapshot captures a fig's value and error so a failed hot reload can be reverted
+type figSnapshot struct {
+	mutagenesis Mutagenesis
+	value       interface{}
+	err         error
+	template    interface{}
+	derived     interface{}
+}
+
+// Watch polls the config files resolved by Load or LoadFile and hot reloads them until ctx is done
+//
+// Example:
+//
+//	ctx, cancel := context.WithCancel(context.Background())
+//	defer cancel()
+//	figs := figtree.With(figtree.Options{ConfigFile: "config.yaml", Tracking: true})
+//	figs.NewInt("workers", 10, "number of workers")
+//	err := figs.Load()
+//	err = figs.Watch(ctx)
+//	for mutation := range figs.Mutations() {
+//		log.Printf("%s changed: %v → %v", mutation.Property, mutation.Old, mutation.New)
+//	}
+func (tree *figTree) Watch(ctx context.Context) error {
+	tree.mu.Lock()
+	defer tree.mu.Unlock()
+	if tree.watcher != nil {
+		return nil
+	}
+	if len(tree.loadedFiles) == 0 {
+		return ErrLoadFailure{"watch", errors.New("no config file has been loaded")}
+	}
+	if len(tree.bindings) > 0 {
+		// the watcher goroutine would write into bound struct fields that the application reads without a lock
+		return ErrLoadFailure{"watch", errors.New("cannot hot reload figs bound by Bind ; follow them through Mutations or Fig[T]")}
+	}
+	ctx, cancel := context.WithCancel(ctx)
+	w := &figWatcher{
+		cancel:   cancel,
+		done:     make(chan struct{}),
+		files:    append([]string(nil), tree.loadedFiles...),
+		interval: tree.watchInterval,
+	}
+	tree.watcher = w
+	go tree.watchLoop(ctx, w)
+	return nil
+}
+
+// StopWatching halts the watcher started by Watch or Options.Watch and waits for it to exit
+func (tree *figTree) StopWatching() {
+	tree.mu.Lock()
+	w := tree.watcher
+	tree.watcher = nil
+	tree.mu.Unlock()
+	if w == nil {
+		return
+	}
+	w.cancel()
+	<-w.done
+}
+
+// rememberFile records a config file that was loaded so Watch knows what to poll
+func (tree *figTree) rememberFile(path string) {
+	tree.mu.Lock()
+	defer tree.mu.Unlock()
+	if !slices.Contains(tree.loadedFiles, path) {
+		tree.loadedFiles = append(tree.loadedFiles, path)
+	}
+	tree.fileStamps[path] = stampOf(path)
+}
+
+// startWatching begins Watch when Options.Watch is enabled and a config file was loaded
+func (tree *figTree) startWatching() error {
+	tree.mu.RLock()
+	ready := tree.watch && len(tree.loadedFiles) > 0
+	tree.mu.RUnlock()
+	if !ready {
+		return nil
+	}
+	return tree.Watch(context.Background())
+}
+
+// stampOf returns the fileStamp of path or an empty fileStamp if it cannot be read
+func stampOf(path string) fileStamp {
+	info, err := os.Stat(path)
+	if err != nil {
+		return fileStamp{}
+	}
+	return fileStamp{modTime: info.ModTime(), size: info.Size()}
+}
+
+// stampedFiles returns files followed by their detached signatures when Options.TrustedKeys is set so a signature
+// written after its config file triggers a hot reload too
+func (tree *figTree) stampedFiles(files []string) []string {
+	if len(tree.trustedKeys) == 0 {
+		return files
+	}
+	stamped := append([]string(nil), files...)
+	for _, f := range files {
+		stamped = append(stamped, f+SignatureSuffix)
+	}
+	return stamped
+}
+
+// watchLoop polls the figWatcher files on its interval and hot reloads them when one changes
+func (tree *figTree) watchLoop(ctx context.Context, w *figWatcher) {
+	defer close(w.done)
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			changed := false
+			tree.mu.Lock()
+			for _, f := range tree.stampedFiles(w.files) {
+				stamp := stampOf(f)
+				if stamp.modTime.IsZero() {
+					continue // the file is missing or mid-write, try again next tick
+				}
+				if stamp != tree.fileStamps[f] {
+					tree.fileStamps[f] = stamp
+					changed = true
+				}
+			}
+			tree.mu.Unlock()
+			if !changed {
+				continue
+			}
+			if err := tree.hotReload(w.files); err != nil {
+				tree.mu.Lock()
+				tree.problems = append(tree.problems, fmt.Errorf("watch: %w", err))
+				tree.mu.Unlock()
+			}
+		}
+	}
+}
+
+// hotReload loads files into a sprout of the figTree and Stores every difference back into the figTree once the
+// sprout passes the validators. When a Store fails or the figTree then fails validateAll, the previous values are
+// restored.
+func (tree *figTree) hotReload(files []string) error {
+	shadow := tree.sprout()
+	if err := shadow.mergeFiles(files); err != nil {
+		return err
+	}
+	if err := shadow.readEnv(); err != nil {
+		return err
+	}
+	shadow.mu.Lock()
+	_, err := shadow.interpolateFigs()
+	shadow.mu.Unlock()
+	if err != nil {
+		return err
+	}
+	previous := tree.snapshot()
+	changes := make(map[string]interface{})
+	for name, snap := range previous {
+		if fruit := shadow.figs[name]; fruit != nil && fruit.template != nil {
+			if !reflect.DeepEqual(fruit.template, snap.template) {
+				changes[name] = cloneRaw(fruit.template) // the figTree derives the value from its new template
+			}
+			continue
+		}
+		value, err := shadow.from(name)
+		if err != nil || value == nil {
+			continue
+		}
+		current, err := toMutagenesis(snap.mutagenesis, value.Value)
+		if err != nil {
+			return ErrInvalidValue{name, err}
+		}
+		if !reflect.DeepEqual(current, snap.value) {
+			changes[name] = current
+		}
+	}
+	if len(changes) == 0 {
+		return nil
+	}
+	if err := shadow.validateAll(); err != nil {
+		return ErrValidationFailure{err}
+	}
+	var errs []error
+	for name, value := range changes {
+		if shadow.isSecret(name) {
+			tree.mu.Lock()
+			tree.markSecret(name) // the new value came from an ENC[...] envelope
+			tree.mu.Unlock()
+		}
+		if err := tree.storeFrom(previous[name].mutagenesis, name, value, shadow.SourceOf(name)); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	if err := errors.Join(errs...); err != nil {
+		tree.restore(previous, changes, err)
+		return err
+	}
+	if err := tree.validateAll(); err != nil {
+		tree.restore(previous, changes, err)
+		return ErrValidationFailure{err}
+	}
+	return nil
+}
+
+// sprout returns a detached copy of the figTree definitions, values and validators without callbacks or tracking
+func (tree *figTree) sprout() *figTree {
+	tree.mu.RLock()
+	defer tree.mu.RUnlock()
+	angel := atomic.Bool{}
+	shadow := &figTree{
+		ConfigFilePath: tree.ConfigFilePath,
+		GlobalRules:    append([]RuleKind(nil), tree.GlobalRules...),
+		ignoreEnv:      tree.ignoreEnv,
+		envPrefix:      tree.envPrefix,
+		envKey:         tree.envKey,
+		env:            tree.env,
+		precedence:     tree.precedence,
+		profiles:       tree.profiles,
+		decrypt:        tree.decrypt,
+		keyFile:        tree.keyFile,
+		trustedKeys:    tree.trustedKeys,
+		angel:          &angel,
+		problems:       make([]error, 0),
+		aliases:        make(map[string]string, len(tree.aliases)),
+		figs:           make(map[string]*figFruit, len(tree.figs)),
+		values:         &sync.Map{},
+		withered:       make(map[string]witheredFig, len(tree.withered)),
+		mutationsCh:    make(chan Mutation, 1),
+		flagSet:        tree.flagSet,
+		branches:       make(map[string]*figBranch, len(tree.branches)),
+	}
+	for path, branch := range tree.branches {
+		shadow.branches[path] = branch
+	}
+	for alias, name := range tree.aliases {
+		shadow.aliases[alias] = name
+	}
+	for name, withered := range tree.withered {
+		shadow.withered[name] = withered
+	}
+	for name, fruit := range tree.figs {
+		shadow.figs[name] = &figFruit{
+			name:        fruit.name,
+			usage:       fruit.usage,
+			Mutagenesis: fruit.Mutagenesis,
+			Rules:       append([]RuleKind(nil), fruit.Rules...),
+			envNames:    fruit.envNames,
+			Mutations:   make([]Mutation, 0),
+			Validators:  append([]FigValidatorFunc(nil), fruit.Validators...),
+			Callbacks:   make([]Callback, 0),
+			provenance:  append([]Provenance(nil), fruit.provenance...),
+			template:    cloneRaw(fruit.template),
+			derived:     cloneRaw(fruit.derived),
+		}
+		value, err := tree.from(name)
+		if err != nil || value == nil {
+			continue
+		}
+		raw, err := toMutagenesis(fruit.Mutagenesis, value.Value)
+		if err != nil {
+			raw = value.Value
+		}
+		shadow.values.Store(name, &Value{Value: cloneRaw(raw), Mutagensis: value.Mutagensis})
+	}
+	return shadow
+}
+
+// snapshot captures the current value and error of every fig on the figTree
+func (tree *figTree) snapshot() map[string]figSnapshot {
+	tree.mu.RLock()
+	defer tree.mu.RUnlock()
+	snaps := make(map[string]figSnapshot, len(tree.figs))
+	for name, fruit := range tree.figs {
+		value, err := tree.from(name)
+		if err != nil || value == nil {
+			continue
+		}
+		raw, err := toMutagenesis(fruit.Mutagenesis, value.Value)
+		if err != nil {
+			continue
+		}
+		snaps[name] = figSnapshot{
+			mutagenesis: fruit.Mutagenesis,
+			value:       cloneRaw(raw),
+			err:         fruit.Error,
+			template:    cloneRaw(fruit.template),
+			derived:     cloneRaw(fruit.derived),
+		}
+	}
+	return snaps
+}
+
+// restore reverts the figs named in changes to their snapshot and issues a Mutation for each reverted fig
+func (tree *figTree) restore(previous map[string]figSnapshot, changes map[string]interface{}, cause error) {
+	reverted := make([]Mutation, 0, len(changes))
+	tree.mu.Lock()
+	for name := range changes {
+		snap := previous[name]
+		value, err := tree.from(name)
+		if err != nil || value == nil {
+			continue
+		}
+		old := cloneRaw(value.Value)
+		value.Value = cloneRaw(snap.value)
+		tree.values.Store(name, value)
+		if fruit, ok := tree.figs[name]; ok && fruit != nil {
+			fruit.Error = snap.err
+			fruit.template, fruit.derived = snap.template, snap.derived
+		}
+		tree.trace(name, Provenance{Source: SourceStore, Origin: "WatchRestore"})
+		tree.ripenFig(name)
+		reverted = append(reverted, tree.redactMutation(Mutation{
+			Property:    name,
+			Mutagenesis: strings.ToLower(string(snap.mutagenesis)),
+			Way:         "WatchRestore",
+			Old:         old,
+			New:         snap.value,
+			When:        time.Now(),
+			Error:       cause,
+			Provenance:  Provenance{Source: SourceStore, Origin: "WatchRestore", Value: cloneRaw(snap.value)},
+		}))
+	}
+	derived, _ := tree.interpolateFigs()
+	tracking := tree.tracking && !tree.angel.Load()
+	tree.mu.Unlock()
+	if !tracking {
+		return
+	}
+	tree.sendMutations(append(reverted, derived...))
+}
+
+// cloneRaw returns a copy of lists and maps so that snapshots do not share memory with live values
+func cloneRaw(raw interface{}) interface{} {
+	switch v := raw.(type) {
+	case []string:
+		return append([]string(nil), v...)
+	case map[string]string:
+		m := make(map[string]string, len(v))
+		for k, x := range v {
+			m[k] = x
+		}
+		return m
+	default:
+		return v
+	}
+}
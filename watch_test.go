@@ -0,0 +1,142 @@
+package figtree
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func waitForMutation(t *testing.T, figs Plant, property string) Mutation {
+	t.Helper()
+	timeout := time.After(3 * time.Second)
+	for {
+		select {
+		case mutation, ok := <-figs.Mutations():
+			if !ok {
+				t.Fatalf("mutations channel closed before %s changed", property)
+			}
+			if mutation.Property == property {
+				return mutation
+			}
+		case <-timeout:
+			t.Fatalf("timed out waiting for a mutation on %s", property)
+		}
+	}
+}
+
+func TestTree_Watch(t *testing.T) {
+	os.Args = []string{os.Args[0]}
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	assert.NoError(t, os.WriteFile(path, []byte("workers: 10\n"), 0644))
+
+	figs := With(Options{
+		ConfigFile:        path,
+		Watch:             true,
+		WatchInterval:     10 * time.Millisecond,
+		Tracking:          true,
+		Harvest:           10,
+		Germinate:         true,
+		IgnoreEnvironment: true,
+	})
+	figs.NewInt("workers", 1, "number of workers")
+	figs.WithValidator("workers", AssureIntInRange(1, 100))
+	assert.NoError(t, figs.Load())
+	defer figs.StopWatching()
+	assert.Equal(t, 10, *figs.Int("workers"))
+
+	t.Run("change", func(t *testing.T) {
+		assert.NoError(t, os.WriteFile(path, []byte("workers: 25\n"), 0644))
+		mutation := waitForMutation(t, figs, "workers")
+		assert.Equal(t, "StoreInt", mutation.Way)
+		assert.Equal(t, 10, mutation.Old)
+		assert.Equal(t, 25, mutation.New)
+		assert.Equal(t, 25, *figs.Int("workers"))
+	})
+
+	t.Run("reject", func(t *testing.T) {
+		assert.NoError(t, os.WriteFile(path, []byte("workers: 500\n"), 0644))
+		assert.Eventually(t, func() bool {
+			return len(figs.Problems()) > 0
+		}, 3*time.Second, 10*time.Millisecond)
+		assert.Equal(t, 25, *figs.Int("workers"))
+		assert.Empty(t, figs.Mutations(), "an invalid value is never stored")
+	})
+}
+
+func TestTree_Watch_Reload(t *testing.T) {
+	os.Args = []string{os.Args[0]}
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	assert.NoError(t, os.WriteFile(path, []byte("workers: 10\n"), 0644))
+	figs := With(Options{
+		ConfigFile:    path,
+		Watch:         true,
+		WatchInterval: time.Millisecond,
+		Germinate:     true,
+		EnvSource:     MapEnv{"NAME": "env"},
+	})
+	figs.NewInt("workers", 1, "number of workers")
+	figs.NewString("name", "", "name")
+	assert.NoError(t, figs.Load())
+	defer figs.StopWatching()
+	for i := 0; i < 50; i++ {
+		assert.NoError(t, os.WriteFile(path, []byte(fmt.Sprintf("workers: %d\n", 10+i)), 0644))
+		assert.NoError(t, figs.Reload())
+		time.Sleep(time.Millisecond)
+	}
+	assert.Equal(t, "env", *figs.String("name"))
+}
+
+func TestTree_StopWatching(t *testing.T) {
+	os.Args = []string{os.Args[0]}
+	path := filepath.Join(t.TempDir(), "config.json")
+	assert.NoError(t, os.WriteFile(path, []byte(`{"name": "fig"}`), 0644))
+
+	figs := With(Options{WatchInterval: 10 * time.Millisecond, Germinate: true, IgnoreEnvironment: true})
+	figs.NewString("name", "", "name")
+	assert.Error(t, figs.Watch(context.Background()), "nothing has been loaded yet")
+	assert.NoError(t, figs.LoadFile(path))
+	assert.NoError(t, figs.Watch(context.Background()))
+	figs.StopWatching()
+	figs.StopWatching()
+
+	assert.NoError(t, os.WriteFile(path, []byte(`{"name": "tree"}`), 0644))
+	time.Sleep(50 * time.Millisecond)
+	assert.Equal(t, "fig", *figs.String("name"))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	assert.NoError(t, figs.Watch(ctx))
+	assert.Eventually(t, func() bool {
+		return *figs.String("name") == "tree"
+	}, 3*time.Second, 10*time.Millisecond)
+	cancel()
+}
+
+func TestTree_Watch_StoreError(t *testing.T) {
+	os.Args = []string{os.Args[0]}
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	assert.NoError(t, os.WriteFile(path, []byte("region: us-east\nworkers: 10\n"), 0644))
+	figs := With(Options{
+		ConfigFile:        path,
+		Watch:             true,
+		WatchInterval:     10 * time.Millisecond,
+		Germinate:         true,
+		IgnoreEnvironment: true,
+	})
+	figs.NewString("region", "", "region").WithRule("region", RulePreventChange)
+	figs.NewInt("workers", 1, "number of workers")
+	assert.NoError(t, figs.Load())
+	defer figs.StopWatching()
+
+	assert.NoError(t, os.WriteFile(path, []byte("region: eu-west\nworkers: 20\n"), 0644))
+	assert.Eventually(t, func() bool {
+		return len(figs.Problems()) > 0
+	}, 3*time.Second, 10*time.Millisecond)
+	assert.ErrorContains(t, figs.Problems()[0], "RulePreventChange")
+	assert.Equal(t, "us-east", *figs.String("region"))
+	assert.Equal(t, 10, *figs.Int("workers"), "a reload that cannot be stored is rolled back")
+}